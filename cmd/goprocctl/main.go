@@ -0,0 +1,789 @@
+// goprocctl is a command-line client for a GoProc server.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/creack/pty"
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+
+	goproc "github.com/beam-cloud/goproc/pkg"
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// Exit codes for failures on this side of the connection. Commands that wait
+// for a process exit with its exit code instead.
+const (
+	exitUsage  = 2
+	exitFailed = 125
+)
+
+const usage = `Usage: goprocctl [flags] <command> [args]
+
+Commands:
+  exec [-name NAME] [-label K=V]... [-cwd DIR] [-env K=V]... [-env-file K=PATH]...
+       [-inherit-env | -inherit-env-only GLOB... | -replace-env] [-expand-env]
+       [-sensitive-env GLOB]... [-wait] [-stdin | -stdin-file PATH | -upload FILE]
+       [-tty] [-idempotency-key KEY] -- ARGS...
+  ps [-l SELECTOR] [-name PREFIX] [-state running|exited|lost] [-sort start|pid|name] [-r]
+     [-limit N [-page TOKEN]]
+  status PID
+  logs [-f] PID
+  wait PID | -l SELECTOR
+  kill PID | -l SELECTOR
+  signal PID SIGNAL | -l SELECTOR SIGNAL
+  attach PID
+
+Flags:
+`
+
+type options struct {
+	addr        string
+	port        uint
+	caFile      string
+	certFile    string
+	keyFile     string
+	insecure    bool
+	token       string
+	output      string
+	dialTimeout time.Duration
+}
+
+// exitError ends the program with code, after printing err if it is set.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func usageError(format string, args ...any) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// exitWith reports the exit code of a remote process as this program's own.
+func exitWith(code int) error {
+	if code == 0 {
+		return nil
+	}
+	// -1 is an exit code the server couldn't collect.
+	if code < 0 || code > 255 {
+		code = exitFailed
+	}
+	return &exitError{code: code}
+}
+
+type command func(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error
+
+var commands = map[string]command{
+	"exec":   execCommand,
+	"ps":     psCommand,
+	"status": statusCommand,
+	"logs":   logsCommand,
+	"wait":   waitCommand,
+	"kill":   killCommand,
+	"signal": signalCommand,
+	"attach": attachCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	opts := &options{}
+
+	fs := flag.NewFlagSet("goprocctl", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.addr, "addr", envOr("GOPROC_ADDR", "localhost"), "server address, or unix:///path/to/socket ($GOPROC_ADDR)")
+	fs.UintVar(&opts.port, "port", 7111, "server port")
+	fs.StringVar(&opts.caFile, "ca", "", "CA certificate file")
+	fs.StringVar(&opts.certFile, "cert", "", "client certificate file for mutual TLS")
+	fs.StringVar(&opts.keyFile, "key", "", "client key file for mutual TLS")
+	fs.BoolVar(&opts.insecure, "insecure", false, "connect without TLS")
+	fs.StringVar(&opts.token, "token", os.Getenv("GOPROC_TOKEN"), "bearer token ($GOPROC_TOKEN)")
+	fs.StringVar(&opts.output, "o", "table", "output format: table or json")
+	fs.DurationVar(&opts.dialTimeout, "dial-timeout", 5*time.Second, "time to wait for the server")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitUsage
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	err := runCommand(opts, fs.Arg(0), fs.Args()[1:])
+
+	var exitErr *exitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		if exitErr.err != nil {
+			fmt.Fprintln(os.Stderr, "goprocctl:", exitErr.err)
+		}
+		return exitErr.code
+	default:
+		fmt.Fprintln(os.Stderr, "goprocctl:", err)
+		return exitFailed
+	}
+}
+
+func runCommand(opts *options, name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return usageError("unknown command %q", name)
+	}
+
+	if opts.output != "table" && opts.output != "json" {
+		return usageError("unknown output format %q", opts.output)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := dial(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer c.Cleanup()
+
+	return cmd(ctx, c, opts, args)
+}
+
+func dial(ctx context.Context, opts *options) (*goproc.GoProcClient, error) {
+	clientOpts := []goproc.ClientOption{goproc.WithDialTimeout(opts.dialTimeout)}
+	unixSocket := strings.HasPrefix(opts.addr, "unix://")
+
+	// TCP connections use TLS unless -insecure is given, trusting the system
+	// roots when -ca is not set.
+	switch {
+	case opts.insecure:
+		clientOpts = append(clientOpts, goproc.WithInsecure())
+	case opts.caFile != "" || opts.certFile != "" || !unixSocket:
+		clientOpts = append(clientOpts, goproc.WithTLSFiles(opts.caFile, opts.certFile, opts.keyFile))
+	}
+
+	if opts.token != "" {
+		clientOpts = append(clientOpts, goproc.WithToken(opts.token))
+	}
+
+	port := opts.port
+	if unixSocket {
+		port = 0
+	}
+
+	return goproc.NewGoProcClient(ctx, opts.addr, port, clientOpts...)
+}
+
+func execCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	var env, envFiles, inheritOnly, sensitiveEnv, labels stringList
+	fs := newFlagSet("exec")
+	name := fs.String("name", "", "name of the process")
+	fs.Var(&labels, "label", "label as KEY=VALUE (repeatable)")
+	cwd := fs.String("cwd", "", "working directory")
+	fs.Var(&env, "env", "environment variable as KEY=VALUE (repeatable)")
+	fs.Var(&envFiles, "env-file", "environment variable read from a server file as KEY=PATH (repeatable)")
+	inheritEnv := fs.Bool("inherit-env", false, "start from the server's environment")
+	fs.Var(&inheritOnly, "inherit-env-only", "start from the server variables matching `GLOB` (repeatable)")
+	replaceEnv := fs.Bool("replace-env", false, "pass only the -env variables, even if there are none")
+	expandEnv := fs.Bool("expand-env", false, "expand ${VAR} in -env values against the server's environment")
+	fs.Var(&sensitiveEnv, "sensitive-env", "redact the values of variables matching `GLOB` in status output (repeatable)")
+	wait := fs.Bool("wait", false, "wait for the process and exit with its exit code")
+	stdin := fs.Bool("stdin", false, "keep the process's stdin open")
+	stdinFile := fs.String("stdin-file", "", "read stdin from `PATH` on the server")
+	upload := fs.String("upload", "", "upload local `FILE` as stdin, - for standard input")
+	tty := fs.Bool("tty", false, "run the process in a pseudo-terminal")
+	key := fs.String("idempotency-key", "", "idempotency key")
+	if err := fs.Parse(args); err != nil {
+		return &exitError{code: exitUsage}
+	}
+
+	if fs.NArg() == 0 {
+		return usageError("exec: no command given")
+	}
+
+	var execOpts []goproc.ExecOption
+	if *cwd != "" {
+		execOpts = append(execOpts, goproc.WithCwd(*cwd))
+	}
+	if len(env) > 0 {
+		execOpts = append(execOpts, goproc.WithEnv(env...))
+	}
+	if *name != "" {
+		execOpts = append(execOpts, goproc.WithName(*name))
+	}
+	if len(labels) > 0 {
+		m := make(map[string]string, len(labels))
+		for _, kv := range labels {
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok {
+				return usageError("exec: -label %q is not KEY=VALUE", kv)
+			}
+			m[k] = v
+		}
+		execOpts = append(execOpts, goproc.WithLabels(m))
+	}
+	for _, kv := range envFiles {
+		name, path, ok := strings.Cut(kv, "=")
+		if !ok {
+			return usageError("exec: -env-file %q is not KEY=PATH", kv)
+		}
+		execOpts = append(execOpts, goproc.WithEnvFile(name, path))
+	}
+	switch {
+	case *inheritEnv && len(inheritOnly) > 0, *replaceEnv && (*inheritEnv || len(inheritOnly) > 0):
+		return usageError("exec: -inherit-env, -inherit-env-only and -replace-env are mutually exclusive")
+	case *inheritEnv:
+		execOpts = append(execOpts, goproc.WithInheritEnv())
+	case len(inheritOnly) > 0:
+		execOpts = append(execOpts, goproc.WithInheritEnvAllowlist(inheritOnly...))
+	case *replaceEnv:
+		execOpts = append(execOpts, goproc.WithReplaceEnv())
+	}
+	if *expandEnv {
+		execOpts = append(execOpts, goproc.WithEnvExpansion())
+	}
+	if len(sensitiveEnv) > 0 {
+		execOpts = append(execOpts, goproc.WithSensitiveEnv(sensitiveEnv...))
+	}
+	if *wait {
+		execOpts = append(execOpts, goproc.WithWait())
+	}
+	if *stdin {
+		execOpts = append(execOpts, goproc.WithStdin())
+	}
+	if *stdinFile != "" {
+		execOpts = append(execOpts, goproc.WithStdinFile(*stdinFile))
+	}
+	if *tty {
+		rows, cols := terminalSize()
+		execOpts = append(execOpts, goproc.WithTTY(rows, cols))
+	}
+	if *key != "" {
+		execOpts = append(execOpts, goproc.WithIdempotencyKey(*key))
+	}
+
+	var res *goproc.ExecResult
+	var err error
+	switch *upload {
+	case "":
+		res, err = c.Exec(ctx, fs.Args(), execOpts...)
+	case "-":
+		res, err = c.ExecUpload(ctx, fs.Args(), os.Stdin, execOpts...)
+	default:
+		var f *os.File
+		if f, err = os.Open(*upload); err == nil {
+			res, err = c.ExecUpload(ctx, fs.Args(), f, execOpts...)
+			f.Close()
+		}
+	}
+	if err != nil && res != nil {
+		return fmt.Errorf("process %d: %w", res.PID, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		if err := printJSON(res); err != nil {
+			return err
+		}
+	} else if res.Exited {
+		fmt.Printf("%d exited with code %d\n", res.PID, res.ExitCode)
+	} else {
+		fmt.Println(res.PID)
+	}
+
+	if res.Exited {
+		return exitWith(res.ExitCode)
+	}
+	return nil
+}
+
+func psCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	fs := newFlagSet("ps")
+	selector := fs.String("l", "", "only list processes whose labels match `SELECTOR`, such as app=web,tier!=db")
+	namePrefix := fs.String("name", "", "only list processes whose name starts with `PREFIX`")
+	state := fs.String("state", "", "only list processes in this state: running, exited or lost")
+	sortBy := fs.String("sort", "start", "order by start, pid or name")
+	reverse := fs.Bool("r", false, "reverse the order")
+	limit := fs.Int("limit", 0, "list at most `N` processes and print the token of the next page")
+	pageToken := fs.String("page", "", "continue from the page `TOKEN` printed by -limit")
+	if err := fs.Parse(args); err != nil {
+		return &exitError{code: exitUsage}
+	}
+
+	if fs.NArg() > 0 {
+		return usageError("ps: unexpected arguments")
+	}
+
+	orders := map[string]proto.ProcessOrder{
+		"start": proto.ProcessOrder_PROCESS_ORDER_START_TIME,
+		"pid":   proto.ProcessOrder_PROCESS_ORDER_PID,
+		"name":  proto.ProcessOrder_PROCESS_ORDER_NAME,
+	}
+	order, ok := orders[*sortBy]
+	if !ok {
+		return usageError("ps: unknown sort order %q", *sortBy)
+	}
+
+	listOpts := []goproc.ListOption{goproc.WithOrder(order, *reverse)}
+	if *selector != "" {
+		listOpts = append(listOpts, goproc.WithLabelSelector(*selector))
+	}
+	if *namePrefix != "" {
+		listOpts = append(listOpts, goproc.WithNamePrefix(*namePrefix))
+	}
+	switch s := goproc.ProcessState(*state); s {
+	case "":
+	case goproc.ProcessRunning, goproc.ProcessExited, goproc.ProcessLost:
+		listOpts = append(listOpts, goproc.WithState(s))
+	default:
+		return usageError("ps: unknown state %q", *state)
+	}
+
+	if *limit <= 0 {
+		if *pageToken != "" {
+			return usageError("ps: -page requires -limit")
+		}
+
+		processes, err := c.ListProcesses(ctx, listOpts...)
+		if err != nil {
+			return err
+		}
+
+		if opts.output == "json" {
+			return printJSON(processes)
+		}
+		return printProcesses(processes)
+	}
+
+	page, err := c.ListProcessesPage(ctx, *limit, *pageToken, listOpts...)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		return printJSON(page)
+	}
+
+	if err := printProcesses(page.Processes); err != nil {
+		return err
+	}
+	if page.NextPageToken != "" {
+		fmt.Fprintf(os.Stderr, "more: -page %s\n", page.NextPageToken)
+	}
+
+	return nil
+}
+
+func statusCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	pid, err := pidArg("status", args)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.Status(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		return printJSON(status)
+	}
+
+	return printProcesses([]*goproc.ProcessStatus{status})
+}
+
+func logsCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	fs := newFlagSet("logs")
+	follow := fs.Bool("f", false, "follow output until the process exits, then exit with its exit code")
+	if err := fs.Parse(args); err != nil {
+		return &exitError{code: exitUsage}
+	}
+
+	pid, err := pidArg("logs", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	res, err := c.StreamOutput(ctx, pid, *follow, os.Stdout, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	if res != nil {
+		return exitWith(res.ExitCode)
+	}
+	return nil
+}
+
+func waitCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	selector, args, err := selectorFlag("wait", args)
+	if err != nil {
+		return err
+	}
+
+	if selector != "" {
+		if len(args) > 0 {
+			return usageError("wait: unexpected arguments")
+		}
+		return waitAll(ctx, c, opts, selector)
+	}
+
+	pid, err := pidArg("wait", args)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.Wait(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		if err := printJSON(res); err != nil {
+			return err
+		}
+	}
+
+	return exitWith(res.ExitCode)
+}
+
+// waitAll waits for the processes matching selector and exits with the exit
+// code of the last one to fail, like a pipeline.
+func waitAll(ctx context.Context, c *goproc.GoProcClient, opts *options, selector string) error {
+	results, err := c.WaitAll(ctx, selector)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PID\tEXIT")
+		for _, res := range results {
+			fmt.Fprintf(w, "%d\t%d\n", res.PID, res.ExitCode)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	exitCode := 0
+	for _, res := range results {
+		if res.ExitCode != 0 {
+			exitCode = res.ExitCode
+		}
+	}
+
+	return exitWith(exitCode)
+}
+
+func killCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	selector, args, err := selectorFlag("kill", args)
+	if err != nil {
+		return err
+	}
+
+	if selector != "" {
+		if len(args) > 0 {
+			return usageError("kill: unexpected arguments")
+		}
+		results, err := c.KillMatching(ctx, selector)
+		if err != nil {
+			return err
+		}
+		return printSignalResults(opts, results)
+	}
+
+	pid, err := pidArg("kill", args)
+	if err != nil {
+		return err
+	}
+
+	return c.Kill(ctx, pid)
+}
+
+func signalCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	selector, args, err := selectorFlag("signal", args)
+	if err != nil {
+		return err
+	}
+
+	if selector != "" {
+		if len(args) != 1 {
+			return usageError("signal: expected SIGNAL")
+		}
+		sig, err := parseSignal(args[0])
+		if err != nil {
+			return err
+		}
+		results, err := c.SignalMatching(ctx, selector, sig)
+		if err != nil {
+			return err
+		}
+		return printSignalResults(opts, results)
+	}
+
+	if len(args) != 2 {
+		return usageError("signal: expected PID and SIGNAL")
+	}
+
+	pid, err := pidArg("signal", args[:1])
+	if err != nil {
+		return err
+	}
+
+	sig, err := parseSignal(args[1])
+	if err != nil {
+		return err
+	}
+
+	return c.Signal(ctx, pid, sig)
+}
+
+// selectorFlag parses the -l flag of commands that act on one pid or on
+// every process matching a label selector.
+func selectorFlag(name string, args []string) (string, []string, error) {
+	fs := newFlagSet(name)
+	selector := fs.String("l", "", "act on every process whose labels match `SELECTOR`")
+	if err := fs.Parse(args); err != nil {
+		return "", nil, &exitError{code: exitUsage}
+	}
+
+	return *selector, fs.Args(), nil
+}
+
+// printSignalResults prints the outcome of a bulk signal per process and
+// fails if any process could not be signalled.
+func printSignalResults(opts *options, results []*goproc.SignalResult) error {
+	type result struct {
+		PID   int    `json:"pid"`
+		Error string `json:"error,omitempty"`
+	}
+
+	failed := false
+	out := make([]result, 0, len(results))
+	for _, res := range results {
+		r := result{PID: res.PID}
+		if res.Err != nil {
+			r.Error = res.Err.Error()
+			failed = true
+		}
+		out = append(out, r)
+	}
+
+	if opts.output == "json" {
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PID\tRESULT")
+		for _, r := range out {
+			status := "ok"
+			if r.Error != "" {
+				status = r.Error
+			}
+			fmt.Fprintf(w, "%d\t%s\n", r.PID, status)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if failed {
+		return &exitError{code: exitFailed}
+	}
+	return nil
+}
+
+func attachCommand(ctx context.Context, c *goproc.GoProcClient, opts *options, args []string) error {
+	pid, err := pidArg("attach", args)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.Status(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	streams := goproc.AttachStreams{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	if status.Stdin || status.TTY {
+		streams.Stdin = os.Stdin
+	}
+
+	// A remote terminal gets the local one in raw mode, so keystrokes such as
+	// Ctrl-C reach the process instead of ending goprocctl.
+	fd := int(os.Stdin.Fd())
+	if status.TTY && term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(fd, state)
+
+		streams.Resize = watchResize(ctx)
+	}
+
+	res, err := c.Attach(ctx, pid, streams)
+	if err != nil {
+		return err
+	}
+
+	if res != nil {
+		return exitWith(res.ExitCode)
+	}
+	return nil
+}
+
+// watchResize sends the size of the local terminal now and whenever it
+// changes.
+func watchResize(ctx context.Context) <-chan goproc.TerminalSize {
+	sizes := make(chan goproc.TerminalSize, 1)
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	winch <- syscall.SIGWINCH
+
+	go func() {
+		defer signal.Stop(winch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-winch:
+				rows, cols := terminalSize()
+				select {
+				case sizes <- goproc.TerminalSize{Rows: rows, Cols: cols}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return sizes
+}
+
+// terminalSize returns the size of the local terminal, or zero for the
+// server's default when stdin isn't one.
+func terminalSize() (uint16, uint16) {
+	size, err := pty.GetsizeFull(os.Stdin)
+	if err != nil {
+		return 0, 0
+	}
+	return size.Rows, size.Cols
+}
+
+func printProcesses(processes []*goproc.ProcessStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PID\tNAME\tSTATE\tEXIT\tCOMMAND")
+
+	for _, p := range processes {
+		exit := "-"
+		if !p.Running() {
+			exit = strconv.Itoa(p.ExitCode)
+		}
+		name := p.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", p.PID, name, p.State, exit, p.Command)
+	}
+
+	return w.Flush()
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("goprocctl "+name, flag.ContinueOnError)
+}
+
+func pidArg(name string, args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, usageError("%s: expected PID", name)
+	}
+
+	pid, err := strconv.Atoi(args[0])
+	if err != nil || pid <= 0 {
+		return 0, usageError("%s: invalid PID %q", name, args[0])
+	}
+
+	return pid, nil
+}
+
+// parseSignal accepts a signal number or name, with or without the SIG
+// prefix.
+func parseSignal(s string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(s); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	sig := unix.SignalNum(name)
+	if sig == 0 {
+		return 0, usageError("unknown signal %q", s)
+	}
+
+	return sig, nil
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
@@ -0,0 +1,369 @@
+package goproc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	auditResultOk    = "ok"
+	auditResultError = "error"
+)
+
+// auditedMethods are the RPCs that change server state and get an audit record.
+var auditedMethods = map[string]bool{
+	"Exec":           true,
+	"ExecUpload":     true,
+	"Kill":           true,
+	"Signal":         true,
+	"KillMatching":   true,
+	"SignalMatching": true,
+	"Attach":         true,
+}
+
+// AuditRecord is one line of the audit log.
+type AuditRecord struct {
+	Time     time.Time  `json:"time"`
+	Method   string     `json:"method"`
+	Caller   string     `json:"caller,omitempty"`
+	Peer     string     `json:"peer,omitempty"`
+	Pid      int32      `json:"pid,omitempty"`
+	Selector string     `json:"selector,omitempty"`
+	Pids     []int32    `json:"pids,omitempty"`
+	Args     []string   `json:"args,omitempty"`
+	Stages   [][]string `json:"stages,omitempty"`
+	Cwd      string     `json:"cwd,omitempty"`
+	EnvKeys  []string   `json:"env_keys,omitempty"`
+	Signal   int32      `json:"signal,omitempty"`
+	// StdinBytes counts the input written to the process over an Attach
+	// session. The input itself is not recorded.
+	StdinBytes int64  `json:"stdin_bytes,omitempty"`
+	Result     string `json:"result"`
+	Error      string `json:"error,omitempty"`
+	ExitCode   *int32 `json:"exit_code,omitempty"`
+}
+
+type auditor struct {
+	mu     sync.Mutex
+	writer *rotatingFile
+}
+
+func newAuditor(cfg AuditConfig) (*auditor, error) {
+	if cfg.Path == "" {
+		return nil, ErrAuditPathNotSet
+	}
+
+	if cfg.MaxSizeMB > 0 && cfg.MaxBackups < 1 {
+		return nil, ErrInvalidAuditBackups
+	}
+
+	w, err := openRotatingFile(cfg.Path, int64(cfg.MaxSizeMB)*1024*1024, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditor{writer: w}, nil
+}
+
+func (a *auditor) record(rec *AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err := a.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return a.writer.Sync()
+}
+
+func (a *auditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.writer.Close()
+}
+
+type auditCallerKey struct{}
+
+// auditCaller receives the caller's name from the authentication interceptors,
+// which run inside the audit interceptor so that calls they reject are
+// audited too.
+type auditCaller struct {
+	name string
+}
+
+func (a *auditor) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	method := path.Base(info.FullMethod)
+	if !auditedMethods[method] {
+		return handler(ctx, req)
+	}
+
+	caller := &auditCaller{}
+	resp, err := handler(context.WithValue(ctx, auditCallerKey{}, caller), req)
+	a.write(newAuditRecord(ctx, caller.name, method, req, resp, err))
+
+	return resp, err
+}
+
+// streamInterceptor audits streaming calls from their first request and their
+// last response. Attach sessions are audited once they end.
+func (a *auditor) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method := path.Base(info.FullMethod)
+	if !auditedMethods[method] {
+		return handler(srv, ss)
+	}
+
+	caller := &auditCaller{}
+	rs := &recordingServerStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), auditCallerKey{}, caller)}
+	err := handler(srv, rs)
+	rec := newAuditRecord(ss.Context(), caller.name, method, rs.first, rs.resp, err)
+	rec.StdinBytes = rs.stdinBytes.Load()
+	a.write(rec)
+
+	return err
+}
+
+func newAuditRecord(ctx context.Context, caller, method string, req, resp any, err error) *AuditRecord {
+	rec := &AuditRecord{
+		Time:   time.Now().UTC(),
+		Method: method,
+		Caller: caller,
+		Result: auditResultOk,
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rec.Peer = p.Addr.String()
+	}
+
+	describeAuditRequest(rec, req)
+
+	if err != nil {
+		rec.Result = auditResultError
+		rec.Error = status.Convert(err).Message()
+	} else {
+		describeAuditResponse(rec, resp)
+	}
+
+	return rec
+}
+
+func (a *auditor) write(rec *AuditRecord) {
+	if err := a.record(rec); err != nil {
+		log.Error().Err(err).Str("method", rec.Method).Msg("Failed to write audit record")
+	}
+}
+
+// recordingServerStream keeps the first message received and the last one
+// sent on a stream, and counts the stdin bytes received by Attach. A read the
+// handler gave up on may still count bytes as the call ends.
+type recordingServerStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	first      any
+	resp       any
+	stdinBytes atomic.Int64
+}
+
+func (s *recordingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordingServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	if s.first == nil {
+		s.first = m
+	}
+	if req, ok := m.(*proto.AttachRequest); ok {
+		s.stdinBytes.Add(int64(len(req.Stdin)))
+	}
+
+	return nil
+}
+
+func (s *recordingServerStream) SendMsg(m any) error {
+	s.resp = m
+	return s.ServerStream.SendMsg(m)
+}
+
+func describeAuditRequest(rec *AuditRecord, req any) {
+	switch r := req.(type) {
+	case *proto.ExecUploadRequest:
+		if r.Exec != nil {
+			describeAuditRequest(rec, r.Exec)
+		}
+	case *proto.ExecProcessRequest:
+		rec.Args = r.Args
+		for _, stage := range r.Pipeline.GetStages() {
+			rec.Stages = append(rec.Stages, stage.Args)
+		}
+		rec.Cwd = r.Cwd
+		rec.EnvKeys = envKeys(requestedEnv(r))
+	case *proto.AttachRequest:
+		rec.Pid = r.Pid
+	case *proto.KillProcessRequest:
+		rec.Pid = r.Pid
+	case *proto.SignalProcessRequest:
+		rec.Pid = r.Pid
+		rec.Signal = r.Signal
+	case *proto.KillMatchingRequest:
+		rec.Selector = r.LabelSelector
+		rec.Signal = int32(syscall.SIGKILL)
+	case *proto.SignalMatchingRequest:
+		rec.Selector = r.LabelSelector
+		rec.Signal = r.Signal
+	}
+}
+
+func describeAuditResponse(rec *AuditRecord, resp any) {
+	if r, ok := resp.(interface {
+		GetOk() bool
+		GetErrorMsg() string
+	}); ok && !r.GetOk() {
+		rec.Result = auditResultError
+		rec.Error = r.GetErrorMsg()
+	}
+
+	switch r := resp.(type) {
+	case *proto.ExecProcessResponse:
+		if r.Pid != 0 {
+			rec.Pid = r.Pid
+		}
+		rec.ExitCode = r.ExitCode
+	case *proto.OutputChunk:
+		// The last chunk of an Attach session carries the exit code.
+		rec.ExitCode = r.ExitCode
+	case *proto.SignalMatchingResponse:
+		// The processes that were signalled.
+		for _, result := range r.Results {
+			if result.Ok {
+				rec.Pids = append(rec.Pids, result.Pid)
+			}
+		}
+	}
+}
+
+// envKeys returns the names of KEY=value environment entries, leaving out
+// the values so secrets never reach the audit log.
+func envKeys(env []string) []string {
+	keys := make([]string, 0, len(env))
+	for _, kv := range env {
+		key, _, _ := strings.Cut(kv, "=")
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// rotatingFile is an append-only file that is rotated to path.1, path.2, ...
+// once it grows past maxSize bytes, keeping at most maxBackups old files. A
+// maxSize of 0 disables rotation.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	rf.file = f
+	rf.size = fi.Size()
+	return nil
+}
+
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	if rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		rf.rotate()
+	}
+
+	// Reopen the file if rotating could not.
+	if rf.file == nil {
+		if err := rf.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// rotate moves the file to path.1, shifting older backups up and dropping the
+// oldest, and opens a new file. If the file can't be moved, records keep
+// being appended to it rather than being lost.
+func (rf *rotatingFile) rotate() {
+	rf.file.Close()
+	rf.file = nil
+
+	os.Remove(fmt.Sprintf("%s.%d", rf.path, rf.maxBackups))
+	for i := rf.maxBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", rf.path, i), fmt.Sprintf("%s.%d", rf.path, i+1))
+	}
+
+	if err := os.Rename(rf.path, rf.path+".1"); err != nil {
+		log.Error().Err(err).Str("path", rf.path).Msg("Failed to rotate audit log")
+	}
+
+	if err := rf.open(); err != nil {
+		log.Error().Err(err).Str("path", rf.path).Msg("Failed to reopen audit log")
+	}
+}
+
+func (rf *rotatingFile) Sync() error {
+	if rf.file == nil {
+		return nil
+	}
+
+	return rf.file.Sync()
+}
+
+func (rf *rotatingFile) Close() error {
+	if rf.file == nil {
+		return nil
+	}
+
+	return rf.file.Close()
+}
@@ -0,0 +1,195 @@
+package goproc
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/beam-cloud/goproc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuditRecordsRejectedCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	audit, err := newAuditor(AuditConfig{Path: path, MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer audit.Close()
+
+	auth := &authenticator{tokens: map[[sha256.Size]byte]*TokenEntry{
+		sha256.Sum256([]byte("ci-token")):        {Name: "ci", Methods: []string{"*"}},
+		sha256.Sum256([]byte("dashboard-token")): {Name: "dashboard", Methods: []string{"Status"}},
+	}}
+	chain := chainUnaryInterceptors([]grpc.UnaryServerInterceptor{audit.unaryInterceptor, auth.unaryInterceptor})
+	info := &grpc.UnaryServerInfo{FullMethod: "/goproc.GoProc/Kill"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return &proto.KillProcessResponse{Ok: true}, nil
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		caller string
+		result string
+	}{
+		{name: "no token", result: auditResultError},
+		{name: "invalid token", token: "nope", result: auditResultError},
+		{name: "not allowed", token: "dashboard-token", caller: "dashboard", result: auditResultError},
+		{name: "allowed", token: "ci-token", caller: "ci", result: auditResultOk},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, bearerPrefix+tt.token))
+		}
+		chain(ctx, &proto.KillProcessRequest{Pid: 42}, info, handler)
+	}
+
+	records := readAuditLog(t, path)
+	if len(records) != len(tests) {
+		t.Fatalf("got %d records, want %d", len(records), len(tests))
+	}
+
+	for i, tt := range tests {
+		rec := records[i]
+		if rec.Method != "Kill" || rec.Pid != 42 {
+			t.Errorf("%s: got method %q pid %d, want Kill 42", tt.name, rec.Method, rec.Pid)
+		}
+		if rec.Caller != tt.caller {
+			t.Errorf("%s: got caller %q, want %q", tt.name, rec.Caller, tt.caller)
+		}
+		if rec.Result != tt.result {
+			t.Errorf("%s: got result %q, want %q", tt.name, rec.Result, tt.result)
+		}
+	}
+}
+
+func readAuditLog(t *testing.T, path string) []AuditRecord {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+func TestRotatingFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxSize    int64
+		maxBackups int
+		writes     int
+		files      []string
+	}{
+		{name: "no rotation", maxSize: 0, maxBackups: 1, writes: 10, files: []string{"audit.log"}},
+		{name: "one backup", maxSize: 25, maxBackups: 1, writes: 10, files: []string{"audit.log", "audit.log.1"}},
+		{name: "backups capped", maxSize: 25, maxBackups: 3, writes: 10, files: []string{"audit.log", "audit.log.1", "audit.log.2", "audit.log.3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			rf, err := openRotatingFile(filepath.Join(dir, "audit.log"), tt.maxSize, tt.maxBackups)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < tt.writes; i++ {
+				if _, err := rf.Write([]byte("0123456789\n")); err != nil {
+					t.Fatalf("write %d: %v", i, err)
+				}
+			}
+			rf.Close()
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, e := range entries {
+				names = append(names, e.Name())
+			}
+			if !slices.Equal(names, tt.files) {
+				t.Fatalf("got files %v, want %v", names, tt.files)
+			}
+
+			// The live file always holds the last record written.
+			data, err := os.ReadFile(filepath.Join(dir, "audit.log"))
+			if err != nil || len(data) == 0 {
+				t.Fatalf("live file is empty: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuditRejectsRotationWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if _, err := newAuditor(AuditConfig{Path: path, MaxSizeMB: 1, MaxBackups: 0}); !errors.Is(err, ErrInvalidAuditBackups) {
+		t.Fatalf("got %v, want %v", err, ErrInvalidAuditBackups)
+	}
+
+	a, err := newAuditor(AuditConfig{Path: path, MaxSizeMB: 0, MaxBackups: 0})
+	if err != nil {
+		t.Fatalf("rotation disabled: unexpected error: %v", err)
+	}
+	a.Close()
+}
+
+func TestRotatingFileReopensAfterFailedRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	rf, err := openRotatingFile(path, 5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	if _, err := rf.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A directory in the way of the backup makes the rename fail.
+	if err := os.Mkdir(path+".1", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path+".1", "keep"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"second\n", "third\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatalf("write after failed rotation: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "first\nsecond\nthird\n" {
+		t.Errorf("got %q, want every record kept in the live file", data)
+	}
+}
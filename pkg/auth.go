@@ -0,0 +1,177 @@
+package goproc
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+	allMethods          = "*"
+	healthServicePrefix = "/grpc.health.v1.Health/"
+)
+
+type callerKey struct{}
+
+// callerFromContext returns the name of the authenticated caller, or an empty
+// string when authentication is disabled.
+func callerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// withCaller returns ctx carrying the caller's name. The name is also passed
+// to an audit record being written around the call, which sees the context
+// from before authentication.
+func withCaller(ctx context.Context, caller string) context.Context {
+	if rec, ok := ctx.Value(auditCallerKey{}).(*auditCaller); ok {
+		rec.name = caller
+	}
+
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+type authenticator struct {
+	tokens map[[sha256.Size]byte]*TokenEntry
+}
+
+func newAuthenticator(cfg AuthConfig) (*authenticator, error) {
+	if cfg.TokenFile == "" {
+		return nil, ErrTokenFileNotSet
+	}
+
+	tf, err := loadTokenFile(cfg.TokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &authenticator{tokens: make(map[[sha256.Size]byte]*TokenEntry)}
+	for i := range tf.Tokens {
+		entry := &tf.Tokens[i]
+		if entry.Token == "" {
+			return nil, fmt.Errorf("token entry %q has an empty token", entry.Name)
+		}
+
+		a.tokens[sha256.Sum256([]byte(entry.Token))] = entry
+	}
+
+	return a, nil
+}
+
+func loadTokenFile(tokenFile string) (*TokenFile, error) {
+	format := ConfigFormat(filepath.Ext(tokenFile))
+	parser, err := GetConfigParser(format)
+	if err != nil {
+		return nil, err
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(tokenFile), parser); err != nil {
+		return nil, err
+	}
+
+	tag := "key"
+	if format == JSONConfigFormat {
+		tag = "json"
+	}
+
+	tf := &TokenFile{}
+	if err := k.UnmarshalWithConf("", tf, koanf.UnmarshalConf{Tag: tag}); err != nil {
+		return nil, err
+	}
+
+	return tf, nil
+}
+
+// authorize checks the bearer token in the incoming metadata against the
+// configured tokens and returns a context carrying the caller's name.
+func (a *authenticator) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	// Health checks come from orchestrators that don't carry tokens.
+	if strings.HasPrefix(fullMethod, healthServicePrefix) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return ctx, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	entry, ok := a.tokens[sha256.Sum256([]byte(strings.TrimPrefix(values[0], bearerPrefix)))]
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+
+	ctx = withCaller(ctx, entry.Name)
+	method := path.Base(fullMethod)
+	if !entry.allows(method) {
+		return ctx, status.Errorf(codes.PermissionDenied, "caller %q is not allowed to call %s", entry.Name, method)
+	}
+
+	return ctx, nil
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *authenticator) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+func (e *TokenEntry) allows(method string) bool {
+	for _, m := range e.Methods {
+		if m == allMethods || m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contextServerStream overrides the context of a grpc.ServerStream so stream
+// handlers see values added by interceptors.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// tokenCredentials attaches a bearer token to every outgoing call.
+type tokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{authorizationHeader: bearerPrefix + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
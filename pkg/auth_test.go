@@ -0,0 +1,90 @@
+package goproc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	tokens := `tokens:
+  - name: ci
+    token: ci-token
+    methods: ["*"]
+  - name: dashboard
+    token: dashboard-token
+    methods: ["Status", "ListProcesses"]
+`
+	if err := os.WriteFile(tokenFile, []byte(tokens), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newAuthenticator(AuthConfig{Enabled: true, TokenFile: tokenFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		method string
+		code   codes.Code
+		caller string
+	}{
+		{name: "all methods", header: "Bearer ci-token", method: "/goproc.GoProc/Exec", code: codes.OK, caller: "ci"},
+		{name: "listed method", header: "Bearer dashboard-token", method: "/goproc.GoProc/Status", code: codes.OK, caller: "dashboard"},
+		{name: "unlisted method", header: "Bearer dashboard-token", method: "/goproc.GoProc/Kill", code: codes.PermissionDenied, caller: "dashboard"},
+		{name: "no token", method: "/goproc.GoProc/Status", code: codes.Unauthenticated},
+		{name: "not a bearer token", header: "Basic ci-token", method: "/goproc.GoProc/Status", code: codes.Unauthenticated},
+		{name: "unknown token", header: "Bearer nope", method: "/goproc.GoProc/Status", code: codes.Unauthenticated},
+		{name: "health check", method: "/grpc.health.v1.Health/Check", code: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, tt.header))
+			}
+
+			ctx, err := a.authorize(ctx, tt.method)
+			if status.Code(err) != tt.code {
+				t.Errorf("got %v, want code %v", err, tt.code)
+			}
+			if caller := callerFromContext(ctx); caller != tt.caller {
+				t.Errorf("got caller %q, want %q", caller, tt.caller)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorErrors(t *testing.T) {
+	dir := t.TempDir()
+	emptyToken := filepath.Join(dir, "empty.yaml")
+	if err := os.WriteFile(emptyToken, []byte("tokens:\n  - name: ci\n    token: \"\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  AuthConfig
+	}{
+		{name: "no token file", cfg: AuthConfig{Enabled: true}},
+		{name: "missing token file", cfg: AuthConfig{Enabled: true, TokenFile: filepath.Join(dir, "missing.yaml")}},
+		{name: "empty token", cfg: AuthConfig{Enabled: true, TokenFile: emptyToken}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newAuthenticator(tt.cfg); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
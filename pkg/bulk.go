@@ -0,0 +1,103 @@
+package goproc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"syscall"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// KillMatching kills every running process whose labels match selector.
+func (m *Manager) KillMatching(ctx context.Context, selector string) ([]*SignalResult, error) {
+	return m.SignalMatching(ctx, selector, syscall.SIGKILL)
+}
+
+// SignalMatching sends sig to every running process whose labels match
+// selector. Failures are reported per process. No process starts while the
+// signals are sent, so none that matches is missed.
+func (m *Manager) SignalMatching(ctx context.Context, selector string, sig syscall.Signal) ([]*SignalResult, error) {
+	sel, err := parseBulkSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	m.startMu.Lock()
+	defer m.startMu.Unlock()
+
+	procs := m.matchingProcesses(sel)
+	results := make([]*SignalResult, 0, len(procs))
+	for _, proc := range procs {
+		if !proc.Running() {
+			continue
+		}
+
+		res := &SignalResult{PID: proc.pid}
+		if res.Err = proc.Signal(sig); res.Err == nil {
+			m.events.publish(proto.ProcessEventType_PROCESS_EVENT_SIGNALED, processInfo(proc), int32(sig))
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// WaitAll blocks until every process whose labels match selector has exited,
+// ctx is done or the manager shuts down.
+func (m *Manager) WaitAll(ctx context.Context, selector string) ([]*WaitResult, error) {
+	sel, err := parseBulkSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := m.stopContext(ctx)
+	defer cancel()
+
+	procs := m.matchingProcesses(sel)
+	results := make([]*WaitResult, 0, len(procs))
+	for _, proc := range procs {
+		select {
+		case <-proc.done:
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+
+		results = append(results, &WaitResult{PID: proc.pid, ExitCode: proc.ExitCode(), StageExitCodes: proc.StageExitCodes()})
+	}
+
+	return results, nil
+}
+
+// parseBulkSelector parses the selector of a bulk operation, which has to
+// select something.
+func parseBulkSelector(s string) (labelSelector, error) {
+	sel, err := parseLabelSelector(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sel) == 0 {
+		return nil, fmt.Errorf("%w: a selector is required", ErrInvalidSelector)
+	}
+
+	return sel, nil
+}
+
+// matchingProcesses returns the processes whose labels match sel, oldest
+// first.
+func (m *Manager) matchingProcesses(sel labelSelector) []*Process {
+	var procs []*Process
+	m.processMap.Range(func(key, value any) bool {
+		if proc := value.(*Process); sel.matches(proc.labels) {
+			procs = append(procs, proc)
+		}
+		return true
+	})
+
+	sort.Slice(procs, func(i, j int) bool {
+		return procs[i].startedAt.Before(procs[j].startedAt)
+	})
+
+	return procs
+}
@@ -0,0 +1,208 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestSignalMatchingSelectors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		selector string
+		// want are the indexes of the started processes that are signaled.
+		want []int
+		err  error
+	}{
+		{name: "equals", selector: "job=build", want: []int{0, 1}},
+		{name: "and", selector: "job=build,tier=db", want: []int{1}},
+		{name: "not equals", selector: "job!=build", want: []int{2, 3}},
+		{name: "exists", selector: "tier", want: []int{0, 1, 2}},
+		{name: "does not exist", selector: "!tier", want: []int{3}},
+		{name: "no match", selector: "job=deploy"},
+		{name: "empty", selector: "", err: ErrInvalidSelector},
+		{name: "invalid", selector: "job=a=b", err: ErrInvalidSelector},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t, GoProcConfig{})
+
+			labels := []map[string]string{
+				{"job": "build", "tier": "web"},
+				{"job": "build", "tier": "db"},
+				{"job": "test", "tier": "web"},
+				{"job": "lint"},
+			}
+			var pids []int
+			for _, l := range labels {
+				res, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Labels: l})
+				if err != nil {
+					t.Fatal(err)
+				}
+				pids = append(pids, res.PID)
+			}
+
+			results, err := m.SignalMatching(ctx, tt.selector, syscall.SIGTERM)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+
+			var got []int
+			for _, res := range results {
+				if res.Err != nil {
+					t.Errorf("signaling %d: %v", res.PID, res.Err)
+				}
+				got = append(got, res.PID)
+			}
+
+			var want []int
+			for _, i := range tt.want {
+				want = append(want, pids[i])
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("signaled %v, want %v", got, want)
+			}
+
+			for i, pid := range pids {
+				if slices.Contains(tt.want, i) {
+					wres, err := m.Wait(ctx, pid)
+					if err != nil || wres.ExitCode != 128+int(syscall.SIGTERM) {
+						t.Errorf("process %d: got %+v, %v, want it terminated", pid, wres, err)
+					}
+					continue
+				}
+
+				if status, err := m.Status(ctx, pid); err != nil || status.State != ProcessRunning {
+					t.Errorf("process %d: got %+v, %v, want it still running", pid, status, err)
+				}
+			}
+		})
+	}
+}
+
+func TestKillMatchingRacingStarts(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	ctx := context.Background()
+	req := &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Labels: map[string]string{"job": "race"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := m.Exec(ctx, req); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	results, err := m.KillMatching(ctx, "job=race")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	killed := make(map[int]bool, len(results))
+	for _, res := range results {
+		killed[res.PID] = true
+	}
+
+	// Starts wait for KillMatching, so every process it missed started after
+	// all of the ones it killed.
+	var lastKilled, firstMissed time.Time
+	sel, err := parseLabelSelector("job=race")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, proc := range m.matchingProcesses(sel) {
+		if killed[proc.pid] {
+			if proc.startedAt.After(lastKilled) {
+				lastKilled = proc.startedAt
+			}
+		} else if firstMissed.IsZero() || proc.startedAt.Before(firstMissed) {
+			firstMissed = proc.startedAt
+		}
+	}
+
+	if len(results) == 0 {
+		t.Fatal("killed no processes")
+	}
+	if !firstMissed.IsZero() && firstMissed.Before(lastKilled) {
+		t.Errorf("missed a process started at %v, before one killed at %v", firstMissed, lastKilled)
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exited", func(t *testing.T) {
+		m := newTestManager(t, GoProcConfig{})
+		for _, script := range []string{"exit 1", "sleep 0.1; exit 2"} {
+			req := &proto.ExecProcessRequest{Args: []string{"sh", "-c", script}, Labels: map[string]string{"job": "batch"}}
+			if _, err := m.Exec(ctx, req); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"sleep", "60"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := m.WaitAll(ctx, "job=batch")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var codes []int
+		for _, res := range results {
+			codes = append(codes, res.ExitCode)
+		}
+		if !slices.Equal(codes, []int{1, 2}) {
+			t.Errorf("got exit codes %v, want [1 2]", codes)
+		}
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		m := newTestManager(t, GoProcConfig{Shutdown: ShutdownConfig{Policy: ShutdownLeave}})
+		res, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Labels: map[string]string{"job": "batch"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer syscall.Kill(res.PID, syscall.SIGKILL)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := m.WaitAll(ctx, "job=batch")
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			t.Fatalf("WaitAll returned %v before shutdown", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		m.Shutdown()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, ErrServerShuttingDown) {
+				t.Errorf("got %v, want %v", err, ErrServerShuttingDown)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("WaitAll still waiting after shutdown")
+		}
+	})
+}
@@ -1,150 +1,1165 @@
 package goproc
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/beam-cloud/goproc/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials/local"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	attachReadSize      = 32 << 10
+	unixScheme          = "unix://"
+	watchRetryInterval  = time.Second
+	retryInitialBackoff = 100 * time.Millisecond
+	retryMaxBackoff     = 2 * time.Second
 )
 
 type GoProcClient struct {
-	ctx    context.Context
 	addr   string
 	port   uint
 	conn   *grpc.ClientConn
 	client proto.GoProcClient
 }
 
-func NewGoProcClient(ctx context.Context, addr string, port uint) (*GoProcClient, error) {
+// ClientOption configures optional behaviour of a GoProcClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	tlsConfig *tls.Config
+	tlsFiles  bool
+	caFile    string
+	certFile  string
+	keyFile   string
+	insecure  bool
+	token     string
+
+	dialTimeout    time.Duration
+	maxMessageSize int
+	keepalive      *keepalive.ClientParameters
+	retries        int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// WithTLS dials the server over TLS using the given config.
+func WithTLS(cfg *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithTLSFiles dials the server over TLS, trusting caFile (or the system roots
+// when empty) and presenting certFile/keyFile as a client certificate when set.
+func WithTLSFiles(caFile, certFile, keyFile string) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsFiles = true
+		o.caFile = caFile
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithInsecure dials the server over plaintext. The server must have been
+// started with tls.insecure enabled.
+func WithInsecure() ClientOption {
+	return func(o *clientOptions) {
+		o.insecure = true
+	}
+}
+
+// WithToken attaches a bearer token to every call made by the client.
+func WithToken(token string) ClientOption {
+	return func(o *clientOptions) {
+		o.token = token
+	}
+}
+
+// WithDialTimeout makes NewGoProcClient connect right away and fail if the
+// server can't be reached within d. Otherwise connecting is deferred to the
+// first call.
+func WithDialTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.dialTimeout = d
+	}
+}
+
+// WithMaxMessageSize sets the largest message the client sends or accepts.
+func WithMaxMessageSize(bytes int) ClientOption {
+	return func(o *clientOptions) {
+		o.maxMessageSize = bytes
+	}
+}
+
+// WithConfig applies the dial timeout and message size limit of a GoProc
+// config, so a client matches the server it was configured alongside.
+func WithConfig(cfg GoProcConfig) ClientOption {
+	return func(o *clientOptions) {
+		o.dialTimeout = time.Duration(cfg.GRPCDialTimeoutS) * time.Second
+		o.maxMessageSize = cfg.GRPCMessageSizeBytes
+	}
+}
+
+// WithKeepalive pings the server after interval without activity and drops
+// the connection if no reply arrives within timeout. The server rejects
+// intervals shorter than 10 seconds.
+func WithKeepalive(interval, timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.keepalive = &keepalive.ClientParameters{
+			Time:                interval,
+			Timeout:             timeout,
+			PermitWithoutStream: true,
+		}
+	}
+}
+
+// WithRetries retries idempotent calls (Status, Wait, ListProcesses and Exec
+// with an idempotency key) up to attempts times in total while the server is
+// unavailable.
+func WithRetries(attempts int) ClientOption {
+	return func(o *clientOptions) {
+		o.retries = attempts
+	}
+}
+
+// WithRetryBackoff sets the delay before the first retry, doubled after every
+// attempt up to max. Delays are jittered so clients don't retry in lockstep.
+func WithRetryBackoff(initial, max time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.initialBackoff = initial
+		o.maxBackoff = max
+	}
+}
+
+func (o *clientOptions) transportCredentials(unixSocket bool) (credentials.TransportCredentials, error) {
+	switch {
+	case o.tlsConfig != nil:
+		return credentials.NewTLS(o.tlsConfig), nil
+	case o.tlsFiles:
+		tlsConfig, err := clientTLSConfig(o.caFile, o.certFile, o.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return credentials.NewTLS(tlsConfig), nil
+	case o.insecure:
+		return insecure.NewCredentials(), nil
+	case unixSocket:
+		return local.NewCredentials(), nil
+	}
+
+	return nil, ErrTransportNotChosen
+}
+
+// NewGoProcClient connects to a GoProc server at addr:port. An addr of the form
+// unix:///path/to/socket connects over a Unix domain socket instead, in which
+// case port is ignored and no TLS option is needed. ctx only bounds the
+// initial connection made with WithDialTimeout; every call takes its own
+// context.
+func NewGoProcClient(ctx context.Context, addr string, port uint, opts ...ClientOption) (*GoProcClient, error) {
 	c := &GoProcClient{
-		ctx:  ctx,
 		addr: addr,
 		port: port,
 	}
 
-	conn, err := grpc.NewClient(fmt.Sprintf("%s:%d", addr, port), grpc.WithInsecure())
+	o := &clientOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	target := fmt.Sprintf("%s:%d", addr, port)
+	unixSocket := strings.HasPrefix(addr, unixScheme)
+	if unixSocket {
+		target = addr
+	}
+
+	creds, err := o.transportCredentials(unixSocket)
+	if err != nil {
+		return nil, err
+	}
+
+	unary := []grpc.UnaryClientInterceptor{withProtocolVersion}
+	if o.retries > 1 {
+		unary = append([]grpc.UnaryClientInterceptor{withRetries(o.retries, o.initialBackoff, o.maxBackoff)}, unary...)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(withProtocolVersionStream),
+	}
+	if o.maxMessageSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(o.maxMessageSize),
+			grpc.MaxCallSendMsgSize(o.maxMessageSize),
+		))
+	}
+	if o.keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*o.keepalive))
+	}
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials{
+			token:      o.token,
+			requireTLS: !o.insecure,
+		}))
+	}
+
+	conn, err := grpc.NewClient(target, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
 	c.conn = conn
 	c.client = proto.NewGoProcClient(conn)
+
+	if o.dialTimeout > 0 {
+		if err := waitForReady(ctx, conn, o.dialTimeout); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w at %s: %v", ErrDialTimeout, target, err)
+		}
+	}
+
 	return c, nil
 }
 
-func (c *GoProcClient) Exec(args []string, cwd string, env []string, wait bool) (int, error) {
-	resp, err := c.client.Exec(c.ctx, &proto.ExecProcessRequest{
-		Args: args,
-		Cwd:  cwd,
-		Env:  env,
-		Wait: &wait,
-	})
+func waitForReady(ctx context.Context, conn *grpc.ClientConn, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return ctx.Err()
+		}
+	}
+}
+
+// idempotentMethods can be retried without changing the outcome.
+var idempotentMethods = map[string]bool{
+	"Status":        true,
+	"Wait":          true,
+	"ListProcesses": true,
+	"WaitAll":       true,
+}
+
+func retryable(method string, req any) bool {
+	if exec, ok := req.(*proto.ExecProcessRequest); ok {
+		return exec.IdempotencyKey != ""
+	}
+
+	return idempotentMethods[path.Base(method)]
+}
+
+// withRetries retries idempotent calls that failed because the server was
+// unavailable, with jittered exponential backoff.
+func withRetries(attempts int, initialBackoff, maxBackoff time.Duration) grpc.UnaryClientInterceptor {
+	if initialBackoff <= 0 {
+		initialBackoff = retryInitialBackoff
+	}
+	if maxBackoff < initialBackoff {
+		maxBackoff = max(retryMaxBackoff, initialBackoff)
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !retryable(method, req) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		backoff := initialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt == attempts {
+				return err
+			}
+
+			delay := backoff/2 + rand.N(backoff/2+1)
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+
+			backoff = min(backoff*2, maxBackoff)
+		}
+	}
+}
+
+// ExecOption configures a process started with Exec.
+type ExecOption func(*proto.ExecProcessRequest)
+
+// WithCwd sets the working directory of the process.
+func WithCwd(cwd string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Cwd = cwd
+	}
+}
+
+// WithEnv sets the environment of the process as KEY=value pairs.
+func WithEnv(env ...string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Env = append(req.Env, env...)
+	}
+}
+
+// WithInheritEnv starts the process with the server's environment, overridden
+// by the variables given with WithEnv.
+func WithInheritEnv() ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.EnvMode = proto.EnvMode_ENV_MODE_INHERIT_AND_OVERRIDE
+	}
+}
+
+// WithInheritEnvAllowlist starts the process with the server variables
+// matching any of the glob patterns, overridden by the variables given with
+// WithEnv.
+func WithInheritEnvAllowlist(patterns ...string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.EnvMode = proto.EnvMode_ENV_MODE_INHERIT_ALLOWLIST
+		req.EnvAllowlist = append(req.EnvAllowlist, patterns...)
+	}
+}
+
+// WithReplaceEnv starts the process with only the variables given with
+// WithEnv, even if there are none.
+func WithReplaceEnv() ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.EnvMode = proto.EnvMode_ENV_MODE_REPLACE
+	}
+}
+
+// WithEnvExpansion expands ${VAR} in the values given with WithEnv against
+// the server's environment.
+func WithEnvExpansion() ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.ExpandEnv = true
+	}
+}
+
+// WithEnvFile sets the variable name to the contents of a file on the server,
+// such as a mounted secret. The value is never reported back.
+func WithEnvFile(name, path string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		if req.EnvFiles == nil {
+			req.EnvFiles = make(map[string]string)
+		}
+		req.EnvFiles[name] = path
+	}
+}
+
+// WithSensitiveEnv redacts the values of the variables matching any of the
+// glob patterns wherever the server reports the process's environment.
+func WithSensitiveEnv(patterns ...string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.SensitiveEnv = append(req.SensitiveEnv, patterns...)
+	}
+}
+
+// WithName names the process.
+func WithName(name string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Name = name
+	}
+}
+
+// WithLabels adds labels to the process, for selecting it in ListProcesses.
+func WithLabels(labels map[string]string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		if req.Labels == nil {
+			req.Labels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			req.Labels[k] = v
+		}
+	}
+}
+
+// WithWait makes Exec return only once the process has exited, with its exit
+// code set in the result.
+func WithWait() ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		wait := true
+		req.Wait = &wait
+	}
+}
+
+// WithStdin keeps the process's stdin open so it can be written through
+// Attach.
+func WithStdin() ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Stdin = true
+	}
+}
+
+// WithStdinData writes data to the process's stdin and then closes it. Large
+// payloads are better sent with ExecUpload.
+func WithStdinData(data []byte) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.StdinData = data
+	}
+}
+
+// WithStdinFile makes the process read stdin from a file on the server,
+// resolved against the working directory.
+func WithStdinFile(path string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.StdinFile = path
+	}
+}
+
+// WithIdempotencyKey makes Exec safe to retry: the server starts one process
+// per key and answers repeats with the first response. WithRetries retries
+// Exec only when it carries a key.
+func WithIdempotencyKey(key string) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.IdempotencyKey = key
+	}
+}
+
+// WithTTY runs the process in a pseudo-terminal of the given size. Zero
+// dimensions use the server's default.
+func WithTTY(rows, cols uint16) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Tty = true
+		req.TerminalSize = &proto.TerminalSize{Rows: uint32(rows), Cols: uint32(cols)}
+	}
+}
+
+// Pipeline is a sequence of commands run by Exec with WithPipeline, each
+// stage's stdout connected to the next stage's stdin. Redirect paths are
+// resolved against the working directory.
+type Pipeline struct {
+	Stages []PipelineStage
+	// StdinFile is read by the first stage.
+	StdinFile string
+	// Stdout receives the last stage's stdout instead of it being captured.
+	Stdout *Redirect
+}
+
+type PipelineStage struct {
+	Args []string
+	// Stderr receives the stage's stderr instead of it being captured.
+	Stderr *Redirect
+}
+
+// Redirect sends output to a file, truncating it unless Append is set. Mode
+// is used if the file is created, 0644 when zero.
+type Redirect struct {
+	Path   string
+	Append bool
+	Mode   os.FileMode
+}
+
+// WithPipeline runs p instead of a single command. Exec must be given no
+// args. The exit code is that of the last stage to fail, and the exit code of
+// every stage is reported in StageExitCodes.
+func WithPipeline(p Pipeline) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		pl := &proto.Pipeline{StdinFile: p.StdinFile, Stdout: p.Stdout.toProto()}
+		for _, stage := range p.Stages {
+			pl.Stages = append(pl.Stages, &proto.PipelineStage{Args: stage.Args, Stderr: stage.Stderr.toProto()})
+		}
+		req.Pipeline = pl
+	}
+}
+
+func (r *Redirect) toProto() *proto.Redirect {
+	if r == nil {
+		return nil
+	}
+
+	return &proto.Redirect{Path: r.Path, Append: r.Append, Mode: uint32(r.Mode.Perm())}
+}
+
+// OutputTarget says where a stream of process output goes. The zero value
+// buffers it, as by default.
+type OutputTarget struct {
+	kind proto.OutputTargetType
+	file *Redirect
+}
+
+// BufferOutput keeps output for Stdout, Stderr and StreamOutput.
+func BufferOutput() OutputTarget {
+	return OutputTarget{kind: proto.OutputTargetType_OUTPUT_TARGET_BUFFER}
+}
+
+// FileOutput writes output straight to a file on the server without
+// buffering it.
+func FileOutput(r Redirect) OutputTarget {
+	return OutputTarget{kind: proto.OutputTargetType_OUTPUT_TARGET_FILE, file: &r}
+}
+
+// DiscardOutput throws output away.
+func DiscardOutput() OutputTarget {
+	return OutputTarget{kind: proto.OutputTargetType_OUTPUT_TARGET_DISCARD}
+}
+
+// MergeOutput sends stderr wherever stdout goes, like 2>&1. It is only valid
+// for stderr.
+func MergeOutput() OutputTarget {
+	return OutputTarget{kind: proto.OutputTargetType_OUTPUT_TARGET_MERGE}
+}
+
+func (t OutputTarget) toProto() *proto.OutputTarget {
+	return &proto.OutputTarget{Type: t.kind, File: t.file.toProto()}
+}
+
+// WithStdout sets where the process's stdout goes.
+func WithStdout(t OutputTarget) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Stdout = t.toProto()
+	}
+}
+
+// WithStderr sets where the process's stderr goes.
+func WithStderr(t OutputTarget) ExecOption {
+	return func(req *proto.ExecProcessRequest) {
+		req.Stderr = t.toProto()
+	}
+}
+
+// ExecResult describes a process started by Exec. ExitCode is only set when
+// Exited is true, which requires WithWait.
+type ExecResult struct {
+	PID            int   `json:"pid"`
+	Exited         bool  `json:"exited"`
+	ExitCode       int   `json:"exit_code"`
+	StageExitCodes []int `json:"stage_exit_codes,omitempty"`
+}
+
+// WaitResult is the outcome of a process that has exited.
+type WaitResult struct {
+	PID            int   `json:"pid"`
+	ExitCode       int   `json:"exit_code"`
+	StageExitCodes []int `json:"stage_exit_codes,omitempty"`
+}
+
+// ProcessStatus describes a process known to the server. ExitCode is -1 while
+// the process is running and when it is unknown.
+type ProcessStatus struct {
+	PID       int               `json:"pid"`
+	Name      string            `json:"name,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Command   string            `json:"command"`
+	Cwd       string            `json:"cwd"`
+	Env       []string          `json:"env"`
+	State     ProcessState      `json:"state"`
+	ExitCode  int               `json:"exit_code"`
+	Stdin     bool              `json:"stdin"`
+	TTY       bool              `json:"tty"`
+	StartedAt time.Time         `json:"started_at"`
+	Stages    []StageStatus     `json:"stages,omitempty"`
+}
+
+// ProcessPage is one page of a process listing. NextPageToken is empty on the
+// last page.
+type ProcessPage struct {
+	Processes     []*ProcessStatus `json:"processes"`
+	NextPageToken string           `json:"next_page_token,omitempty"`
+}
+
+// ListOption filters and orders the processes returned by ListProcesses.
+type ListOption func(*proto.ListProcessesRequest)
+
+// WithLabelSelector lists only the processes whose labels match sel, such as
+// "tenant=acme,tier!=batch".
+func WithLabelSelector(sel string) ListOption {
+	return func(req *proto.ListProcessesRequest) {
+		req.LabelSelector = sel
+	}
+}
+
+// WithState lists only the processes in state.
+func WithState(state ProcessState) ListOption {
+	return func(req *proto.ListProcessesRequest) {
+		req.State = processStates[state]
+	}
+}
+
+// WithNamePrefix lists only the processes whose name starts with prefix.
+func WithNamePrefix(prefix string) ListOption {
+	return func(req *proto.ListProcessesRequest) {
+		req.NamePrefix = prefix
+	}
+}
+
+// WithOrder sorts the listing. The default is by start time, oldest first.
+func WithOrder(order proto.ProcessOrder, descending bool) ListOption {
+	return func(req *proto.ListProcessesRequest) {
+		req.OrderBy = order
+		req.Descending = descending
+	}
+}
+
+func listRequest(opts []ListOption) *proto.ListProcessesRequest {
+	req := &proto.ListProcessesRequest{}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	return req
+}
+
+// SignalResult is the outcome of a bulk signal for one process. Err is nil if
+// the signal was delivered.
+type SignalResult struct {
+	PID int   `json:"pid"`
+	Err error `json:"-"`
+}
+
+// StageStatus describes one stage of a pipeline. ExitCode is -1 while the
+// stage is running.
+type StageStatus struct {
+	PID      int    `json:"pid"`
+	Command  string `json:"command"`
+	Running  bool   `json:"running"`
+	ExitCode int    `json:"exit_code"`
+}
+
+func (s *ProcessStatus) Running() bool {
+	return s.State == ProcessRunning
+}
+
+var protoStates = map[proto.ProcessState]ProcessState{
+	proto.ProcessState_PROCESS_STATE_RUNNING: ProcessRunning,
+	proto.ProcessState_PROCESS_STATE_EXITED:  ProcessExited,
+	proto.ProcessState_PROCESS_STATE_LOST:    ProcessLost,
+}
+
+func processStatusOf(info *proto.ProcessInfo) *ProcessStatus {
+	state, ok := protoStates[info.State]
+	if !ok {
+		// Servers predating the state field only report running.
+		state = ProcessExited
+		if info.Running {
+			state = ProcessRunning
+		}
+	}
+
+	status := &ProcessStatus{
+		PID:      int(info.Pid),
+		Name:     info.Name,
+		Labels:   info.Labels,
+		Command:  info.Cmd,
+		Cwd:      info.Cwd,
+		Env:      info.Env,
+		State:    state,
+		ExitCode: int(info.ExitCode),
+		Stdin:    info.Stdin,
+		TTY:      info.Tty,
+		Stages:   stageStatusesOf(info.Stages),
+	}
+	if info.StartedAtUnixNano != 0 {
+		status.StartedAt = time.Unix(0, info.StartedAtUnixNano)
+	}
+
+	return status
+}
+
+func stageStatusesOf(infos []*proto.PipelineStageInfo) []StageStatus {
+	if len(infos) == 0 {
+		return nil
+	}
+
+	stages := make([]StageStatus, 0, len(infos))
+	for _, info := range infos {
+		stage := StageStatus{PID: int(info.Pid), Command: info.Cmd, Running: info.ExitCode == nil, ExitCode: -1}
+		if info.ExitCode != nil {
+			stage.ExitCode = int(*info.ExitCode)
+		}
+		stages = append(stages, stage)
+	}
+
+	return stages
+}
+
+func execRequest(args []string, opts []ExecOption) *proto.ExecProcessRequest {
+	req := &proto.ExecProcessRequest{Args: args}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	return req
+}
+
+func ints(values []int32) []int {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := make([]int, 0, len(values))
+	for _, v := range values {
+		out = append(out, int(v))
+	}
+
+	return out
+}
+
+// Exec starts a process. If the call fails once the process has started,
+// such as while waiting for it, the result carrying its pid is returned with
+// the error.
+func (c *GoProcClient) Exec(ctx context.Context, args []string, opts ...ExecOption) (*ExecResult, error) {
+	resp, err := c.client.Exec(ctx, execRequest(args, opts))
 	if err != nil {
-		return -1, err
+		return startedResult(err), err
+	}
+
+	return execResultOf(resp)
+}
+
+// ExecUpload runs Exec with stdin streamed from r, which is closed for the
+// process once r reaches end-of-file. Use it for payloads too large for
+// WithStdinData. If reading r fails, the call is cancelled and the server
+// kills the process.
+func (c *GoProcClient) ExecUpload(ctx context.Context, args []string, r io.Reader, opts ...ExecOption) (*ExecResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.ExecUpload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send fails with io.EOF once the server has ended the call, whose
+	// status then comes from CloseAndRecv.
+	err = stream.Send(&proto.ExecUploadRequest{Exec: execRequest(args, opts)})
+	buf := make([]byte, uploadChunkSize)
+	for err == nil {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			err = stream.Send(&proto.ExecUploadRequest{Stdin: bytes.Clone(buf[:n])})
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return startedResult(err), err
+	}
+
+	return execResultOf(resp)
+}
+
+// startedResult returns the result of an exec that failed after starting its
+// process, from the pid the server reports with the error, or nil.
+func startedResult(err error) *ExecResult {
+	var remoteErr *RemoteError
+	if !errors.As(err, &remoteErr) || remoteErr.PID() == 0 {
+		return nil
 	}
+
+	return &ExecResult{PID: remoteErr.PID(), ExitCode: -1}
+}
+
+func execResultOf(resp *proto.ExecProcessResponse) (*ExecResult, error) {
 	if !resp.Ok {
-		return -1, fmt.Errorf(resp.ErrorMsg)
+		var started *ExecResult
+		if resp.Pid != 0 {
+			started = &ExecResult{PID: int(resp.Pid), ExitCode: -1}
+		}
+		return started, legacyError(resp.ErrorMsg)
+	}
+
+	result := &ExecResult{PID: int(resp.Pid), ExitCode: -1}
+	if resp.ExitCode != nil {
+		result.Exited = true
+		result.ExitCode = int(*resp.ExitCode)
+		result.StageExitCodes = ints(resp.StageExitCodes)
 	}
 
-	return int(resp.Pid), nil
+	return result, nil
 }
 
-func (c *GoProcClient) Wait(pid int) (int, error) {
-	resp, err := c.client.Wait(c.ctx, &proto.WaitProcessRequest{
+func (c *GoProcClient) Wait(ctx context.Context, pid int) (*WaitResult, error) {
+	resp, err := c.client.Wait(ctx, &proto.WaitProcessRequest{
 		Pid: int32(pid),
 	})
 	if err != nil {
-		return -1, err
+		return nil, err
+	}
+	if !resp.Ok {
+		return nil, legacyError(resp.ErrorMsg)
+	}
+
+	return &WaitResult{PID: pid, ExitCode: int(resp.ExitCode), StageExitCodes: ints(resp.StageExitCodes)}, nil
+}
+
+// KillMatching kills every running process whose labels match selector.
+func (c *GoProcClient) KillMatching(ctx context.Context, selector string) ([]*SignalResult, error) {
+	resp, err := c.client.KillMatching(ctx, &proto.KillMatchingRequest{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	return signalResultsOf(resp)
+}
+
+// SignalMatching sends sig to every running process whose labels match
+// selector.
+func (c *GoProcClient) SignalMatching(ctx context.Context, selector string, sig syscall.Signal) ([]*SignalResult, error) {
+	resp, err := c.client.SignalMatching(ctx, &proto.SignalMatchingRequest{
+		LabelSelector: selector,
+		Signal:        int32(sig),
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return signalResultsOf(resp)
+}
+
+func signalResultsOf(resp *proto.SignalMatchingResponse) ([]*SignalResult, error) {
 	if !resp.Ok {
-		return -1, fmt.Errorf(resp.ErrorMsg)
+		return nil, legacyError(resp.ErrorMsg)
+	}
+
+	results := make([]*SignalResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		res := &SignalResult{PID: int(r.Pid)}
+		if !r.Ok {
+			res.Err = resultError(r.ErrorMsg, codes.Code(r.Code), r.Reason)
+		}
+		results = append(results, res)
 	}
 
-	return int(resp.ExitCode), nil
+	return results, nil
 }
 
-func (c *GoProcClient) Kill(pid int) error {
-	resp, err := c.client.Kill(c.ctx, &proto.KillProcessRequest{
+// WaitAll blocks until every process whose labels match selector has exited.
+func (c *GoProcClient) WaitAll(ctx context.Context, selector string) ([]*WaitResult, error) {
+	resp, err := c.client.WaitAll(ctx, &proto.WaitAllRequest{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Ok {
+		return nil, legacyError(resp.ErrorMsg)
+	}
+
+	results := make([]*WaitResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, &WaitResult{PID: int(r.Pid), ExitCode: int(r.ExitCode), StageExitCodes: ints(r.StageExitCodes)})
+	}
+
+	return results, nil
+}
+
+func (c *GoProcClient) Kill(ctx context.Context, pid int) error {
+	resp, err := c.client.Kill(ctx, &proto.KillProcessRequest{
 		Pid: int32(pid),
 	})
 	if err != nil {
 		return err
 	}
 	if !resp.Ok {
-		return fmt.Errorf(resp.ErrorMsg)
+		return legacyError(resp.ErrorMsg)
 	}
 
 	return nil
 }
 
-func (c *GoProcClient) Signal(pid int, signal int) error {
-	resp, err := c.client.Signal(c.ctx, &proto.SignalProcessRequest{
+func (c *GoProcClient) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	resp, err := c.client.Signal(ctx, &proto.SignalProcessRequest{
 		Pid:    int32(pid),
-		Signal: int32(signal),
+		Signal: int32(sig),
 	})
 	if err != nil {
 		return err
 	}
 	if !resp.Ok {
-		return fmt.Errorf(resp.ErrorMsg)
+		return legacyError(resp.ErrorMsg)
 	}
 
 	return nil
 }
 
-func (c *GoProcClient) Status(pid int) (int, error) {
-	resp, err := c.client.Status(c.ctx, &proto.StatusProcessRequest{
+func (c *GoProcClient) Status(ctx context.Context, pid int) (*ProcessStatus, error) {
+	resp, err := c.client.Status(ctx, &proto.StatusProcessRequest{
 		Pid: int32(pid),
 	})
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
-
 	if !resp.Ok {
-		return -1, fmt.Errorf(resp.ErrorMsg)
+		return nil, legacyError(resp.ErrorMsg)
 	}
 
-	return int(resp.Process.ExitCode), nil
+	return processStatusOf(resp.Process), nil
 }
 
-func (c *GoProcClient) Stdout(pid int) (string, error) {
-	resp, err := c.client.Stdout(c.ctx, &proto.StdoutProcessRequest{
+func (c *GoProcClient) Stdout(ctx context.Context, pid int) (string, error) {
+	resp, err := c.client.Stdout(ctx, &proto.StdoutProcessRequest{
 		Pid: int32(pid),
 	})
 	if err != nil {
 		return "", err
 	}
 	if !resp.Ok {
-		return "", fmt.Errorf(resp.ErrorMsg)
+		return "", legacyError(resp.ErrorMsg)
 	}
 
 	return resp.Stdout, nil
 }
 
-func (c *GoProcClient) Stderr(pid int) (string, error) {
-	resp, err := c.client.Stderr(c.ctx, &proto.StderrProcessRequest{
+func (c *GoProcClient) Stderr(ctx context.Context, pid int) (string, error) {
+	resp, err := c.client.Stderr(ctx, &proto.StderrProcessRequest{
 		Pid: int32(pid),
 	})
 	if err != nil {
 		return "", err
 	}
 	if !resp.Ok {
-		return "", fmt.Errorf(resp.ErrorMsg)
+		return "", legacyError(resp.ErrorMsg)
 	}
 
 	return resp.Stderr, nil
 }
 
-func (c *GoProcClient) ListProcesses() ([]*proto.ProcessInfo, error) {
-	resp, err := c.client.ListProcesses(c.ctx, &proto.ListProcessesRequest{})
+// ListProcesses returns every process matching opts.
+func (c *GoProcClient) ListProcesses(ctx context.Context, opts ...ListOption) ([]*ProcessStatus, error) {
+	page, err := c.listProcesses(ctx, listRequest(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Processes, nil
+}
+
+// ListProcessesPage returns up to pageSize processes matching opts, starting
+// after the page pageToken came with. Pass an empty token for the first page.
+func (c *GoProcClient) ListProcessesPage(ctx context.Context, pageSize int, pageToken string, opts ...ListOption) (*ProcessPage, error) {
+	req := listRequest(opts)
+	req.PageSize = int32(pageSize)
+	req.PageToken = pageToken
+
+	return c.listProcesses(ctx, req)
+}
+
+func (c *GoProcClient) listProcesses(ctx context.Context, req *proto.ListProcessesRequest) (*ProcessPage, error) {
+	resp, err := c.client.ListProcesses(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	if !resp.Ok {
-		return nil, fmt.Errorf(resp.ErrorMsg)
+		return nil, legacyError(resp.ErrorMsg)
+	}
+
+	page := &ProcessPage{Processes: make([]*ProcessStatus, 0, len(resp.Processes)), NextPageToken: resp.NextPageToken}
+	for _, info := range resp.Processes {
+		page.Processes = append(page.Processes, processStatusOf(info))
+	}
+
+	return page, nil
+}
+
+// StreamOutput copies the output of a process to stdout and stderr, either of
+// which may be nil to discard it. Output already buffered is copied first;
+// with follow, new output is copied until the process exits, and its exit is
+// returned. Otherwise the result is nil.
+func (c *GoProcClient) StreamOutput(ctx context.Context, pid int, follow bool, stdout, stderr io.Writer) (*WaitResult, error) {
+	stream, err := c.client.StreamOutput(ctx, &proto.StreamOutputRequest{
+		Pid:    int32(pid),
+		Follow: follow,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return receiveOutput(pid, stream.Recv, stdout, stderr)
+}
+
+// AttachStreams connects local streams to an attached process. Stdin is
+// copied to the process and its stdin closed at EOF. Every size received on
+// Resize is applied to the process's terminal.
+type AttachStreams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	Resize <-chan TerminalSize
+}
+
+// Attach connects streams to a running process until it exits, and returns
+// its exit. The process must have been started WithStdin or WithTTY for input
+// to be accepted.
+func (c *GoProcClient) Attach(ctx context.Context, pid int, streams AttachStreams) (*WaitResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.Attach(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sends come from the stdin and resize goroutines, and a gRPC stream
+	// only supports one sender at a time.
+	var sendMu sync.Mutex
+	send := func(req *proto.AttachRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(req)
+	}
+
+	if err := send(&proto.AttachRequest{Pid: int32(pid)}); err != nil {
+		return nil, err
+	}
+
+	sendAttachInput(ctx, streams, send)
+
+	return receiveOutput(pid, stream.Recv, streams.Stdout, streams.Stderr)
+}
+
+// sendAttachInput copies streams.Stdin and streams.Resize to send in the
+// background until ctx is done, closing stdin at EOF.
+func sendAttachInput(ctx context.Context, streams AttachStreams, send func(*proto.AttachRequest) error) {
+	if streams.Stdin != nil {
+		go func() {
+			buf := make([]byte, attachReadSize)
+			for {
+				n, err := streams.Stdin.Read(buf)
+				if n > 0 {
+					if send(&proto.AttachRequest{Stdin: bytes.Clone(buf[:n])}) != nil {
+						return
+					}
+				}
+				if err != nil {
+					send(&proto.AttachRequest{CloseStdin: true})
+					return
+				}
+			}
+		}()
+	}
+
+	if streams.Resize != nil {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case size := <-streams.Resize:
+					req := &proto.AttachRequest{Resize: &proto.TerminalSize{Rows: uint32(size.Rows), Cols: uint32(size.Cols)}}
+					if send(req) != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+}
+
+func receiveOutput(pid int, recv func() (*proto.OutputChunk, error), stdout, stderr io.Writer) (*WaitResult, error) {
+	w := &outputWriter{pid: pid, stdout: stdout, stderr: stderr}
+	for {
+		chunk, err := recv()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := w.write(chunk); err != nil {
+			return nil, err
+		}
+
+		if w.exit != nil {
+			return w.exit, nil
+		}
 	}
+}
+
+// outputWriter copies output chunks to local writers, either of which may be
+// nil to discard the stream, and records the exit carried by the last chunk.
+type outputWriter struct {
+	pid    int
+	stdout io.Writer
+	stderr io.Writer
+	exit   *WaitResult
+}
+
+func (w *outputWriter) write(chunk *proto.OutputChunk) error {
+	if chunk.ExitCode != nil {
+		w.exit = &WaitResult{PID: w.pid, ExitCode: int(*chunk.ExitCode)}
+		return nil
+	}
+
+	dst := w.stdout
+	if chunk.Stream == proto.OutputStream_OUTPUT_STREAM_STDERR {
+		dst = w.stderr
+	}
+	if dst == nil {
+		return nil
+	}
+
+	_, err := dst.Write(chunk.Data)
+	return err
+}
+
+// WatchEvents streams process lifecycle events to fn until ctx is cancelled
+// or fn returns an error. If the stream breaks, it is re-established from the
+// last event received so no events are missed.
+func (c *GoProcClient) WatchEvents(ctx context.Context, req *proto.WatchEventsRequest, fn func(*proto.ProcessEvent) error) error {
+	req = protobuf.Clone(req).(*proto.WatchEventsRequest)
 
-	return resp.Processes, nil
+	for {
+		err := c.watchEvents(ctx, req, fn)
+		if status.Code(err) != codes.Unavailable && status.Code(err) != codes.ResourceExhausted {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+func (c *GoProcClient) watchEvents(ctx context.Context, req *proto.WatchEventsRequest, fn func(*proto.ProcessEvent) error) error {
+	stream, err := c.client.WatchEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		req.ResumeToken = ev.ResumeToken
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
 }
 
 func (c *GoProcClient) Cleanup() error {
@@ -0,0 +1,50 @@
+package goproc
+
+import (
+	"crypto/tls"
+	"errors"
+	"testing"
+)
+
+func TestTransportCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []ClientOption
+		unixSocket bool
+		protocol   string
+		wantErr    bool
+		err        error
+	}{
+		{name: "none", wantErr: true, err: ErrTransportNotChosen},
+		{name: "system roots", opts: []ClientOption{WithTLSFiles("", "", "")}, protocol: "tls"},
+		{name: "tls config", opts: []ClientOption{WithTLS(&tls.Config{})}, protocol: "tls"},
+		{name: "insecure", opts: []ClientOption{WithInsecure()}, protocol: "insecure"},
+		{name: "unix socket", unixSocket: true, protocol: "local"},
+		{name: "tls on unix socket", opts: []ClientOption{WithTLSFiles("", "", "")}, unixSocket: true, protocol: "tls"},
+		{name: "missing ca", opts: []ClientOption{WithTLSFiles("/nonexistent/ca.crt", "", "")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &clientOptions{}
+			for _, opt := range tt.opts {
+				opt(o)
+			}
+
+			creds, err := o.transportCredentials(tt.unixSocket)
+			if tt.wantErr {
+				if err == nil || (tt.err != nil && !errors.Is(err, tt.err)) {
+					t.Fatalf("got error %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := creds.Info().SecurityProtocol; got != tt.protocol {
+				t.Errorf("got protocol %q, want %q", got, tt.protocol)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package goproc
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// redactedValue replaces environment values that must not be echoed back.
+const redactedValue = "[redacted]"
+
+var (
+	envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	envRefPattern  = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// checkEnv checks the environment options of an exec request.
+func checkEnv(req *proto.ExecProcessRequest) error {
+	switch req.EnvMode {
+	case proto.EnvMode_ENV_MODE_UNSPECIFIED, proto.EnvMode_ENV_MODE_REPLACE, proto.EnvMode_ENV_MODE_INHERIT_AND_OVERRIDE:
+		if len(req.EnvAllowlist) > 0 {
+			return fmt.Errorf("%w: env_allowlist requires ENV_MODE_INHERIT_ALLOWLIST", ErrInvalidEnv)
+		}
+	case proto.EnvMode_ENV_MODE_INHERIT_ALLOWLIST:
+		if err := checkEnvPatterns(req.EnvAllowlist); err != nil {
+			return fmt.Errorf("%w: env_allowlist: %w", ErrInvalidEnv, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown env mode %d", ErrInvalidEnv, req.EnvMode)
+	}
+
+	if err := checkEnvPatterns(req.SensitiveEnv); err != nil {
+		return fmt.Errorf("%w: sensitive_env: %w", ErrInvalidEnv, err)
+	}
+
+	for name, file := range req.EnvFiles {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: invalid env_files name %q", ErrInvalidEnv, name)
+		}
+		if file == "" {
+			return fmt.Errorf("%w: env_files entry %s has no path", ErrInvalidEnv, name)
+		}
+	}
+
+	return nil
+}
+
+// requestedEnv returns the variables an exec request sets itself, with the
+// values of env_files left out. Policy rules are matched against these.
+func requestedEnv(req *proto.ExecProcessRequest) []string {
+	env := append([]string(nil), req.Env...)
+	for _, name := range sortedKeys(req.EnvFiles) {
+		env = append(env, name+"=")
+	}
+
+	return env
+}
+
+// composeEnv builds the environment of the process an exec request starts:
+// the server's variables its mode passes on, then env, then the variables
+// read from env_files. Files are read relative to cwd. reported is the same
+// environment as clients get to see it, with env entries as given rather than
+// expanded, and the values inherited from the server or read from files
+// redacted.
+func composeEnv(req *proto.ExecProcessRequest) (env, reported []string, err error) {
+	var b envBuilder
+
+	switch req.EnvMode {
+	case proto.EnvMode_ENV_MODE_INHERIT_AND_OVERRIDE:
+		for _, kv := range os.Environ() {
+			b.set(kv, redactValue(kv))
+		}
+	case proto.EnvMode_ENV_MODE_INHERIT_ALLOWLIST:
+		for _, kv := range os.Environ() {
+			name, _, _ := strings.Cut(kv, "=")
+			if matchAnyGlob(req.EnvAllowlist, name) {
+				b.set(kv, redactValue(kv))
+			}
+		}
+	}
+
+	for _, kv := range req.Env {
+		if req.ExpandEnv {
+			b.set(expandEnv(kv), kv)
+		} else {
+			b.set(kv, kv)
+		}
+	}
+
+	for _, name := range sortedKeys(req.EnvFiles) {
+		data, err := os.ReadFile(resolvePath(req.Cwd, req.EnvFiles[name]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: env file for %s: %w", ErrInvalidEnv, name, err)
+		}
+
+		value := strings.TrimSuffix(string(data), "\n")
+		value = strings.TrimSuffix(value, "\r")
+		b.set(name+"="+value, name+"="+redactedValue)
+	}
+
+	// exec.Cmd gives a nil environment the server's own, which requests
+	// without a mode keep getting.
+	if req.EnvMode == proto.EnvMode_ENV_MODE_REPLACE && b.env == nil {
+		return []string{}, []string{}, nil
+	}
+
+	return b.env, b.reported, nil
+}
+
+// redactValue returns a KEY=value entry with its value redacted.
+func redactValue(kv string) string {
+	name, _, _ := strings.Cut(kv, "=")
+	return name + "=" + redactedValue
+}
+
+// expandEnv replaces ${VAR} in the value of a KEY=value entry with the
+// server's value of VAR.
+func expandEnv(kv string) string {
+	name, value, ok := strings.Cut(kv, "=")
+	if !ok {
+		return kv
+	}
+
+	return name + "=" + envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		return os.Getenv(ref[2 : len(ref)-1])
+	})
+}
+
+// envBuilder collects KEY=value entries along with the form each is reported
+// in, a later entry replacing an earlier one of the same name in place.
+type envBuilder struct {
+	env      []string
+	reported []string
+	index    map[string]int
+}
+
+func (b *envBuilder) set(kv, reported string) {
+	if b.index == nil {
+		b.index = make(map[string]int)
+	}
+
+	name, _, _ := strings.Cut(kv, "=")
+	if i, ok := b.index[name]; ok {
+		b.env[i] = kv
+		b.reported[i] = reported
+		return
+	}
+
+	b.index[name] = len(b.env)
+	b.env = append(b.env, kv)
+	b.reported = append(b.reported, reported)
+}
+
+// envRedactor replaces the values of sensitive environment variables with
+// redactedValue.
+type envRedactor struct {
+	patterns []string
+}
+
+func newEnvRedactor(cfg RedactionConfig) (*envRedactor, error) {
+	if err := checkEnvPatterns(cfg.EnvPatterns); err != nil {
+		return nil, fmt.Errorf("redaction: %w", err)
+	}
+
+	return &envRedactor{patterns: upperAll(cfg.EnvPatterns)}, nil
+}
+
+// redact returns env with the values of the variables matching the server's
+// patterns or sensitive redacted.
+func (r *envRedactor) redact(env []string, sensitive []string) []string {
+	patterns := append(upperAll(sensitive), r.patterns...)
+	if len(env) == 0 || len(patterns) == 0 {
+		return env
+	}
+
+	out := make([]string, 0, len(env))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if matchAnyGlob(patterns, strings.ToUpper(name)) {
+			kv = redactValue(kv)
+		}
+		out = append(out, kv)
+	}
+
+	return out
+}
+
+func checkEnvPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+
+	return nil
+}
+
+func upperAll(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, strings.ToUpper(v))
+	}
+
+	return out
+}
+
+// reportedEnv returns the environment of the process as reported to clients
+// and saved in the registry.
+func (p *Process) reportedEnv() []string {
+	if p.reportEnv != nil {
+		return p.reportEnv
+	}
+
+	return p.cmd.Env
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,155 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestComposeEnv(t *testing.T) {
+	t.Setenv("GOPROC_TEST_HOME", "/home/test")
+	t.Setenv("GOPROC_TEST_LANG", "C")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("s3cret\r\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		req      *proto.ExecProcessRequest
+		env      []string
+		reported []string
+		inherits bool
+	}{
+		{
+			name: "no mode and no env",
+			req:  &proto.ExecProcessRequest{},
+		},
+		{
+			name:     "no mode",
+			req:      &proto.ExecProcessRequest{Env: []string{"A=1"}},
+			env:      []string{"A=1"},
+			reported: []string{"A=1"},
+		},
+		{
+			name:     "replace without env",
+			req:      &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_REPLACE},
+			env:      []string{},
+			reported: []string{},
+		},
+		{
+			name:     "later entries win in place",
+			req:      &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_REPLACE, Env: []string{"A=1", "B=2", "A=3"}},
+			env:      []string{"A=3", "B=2"},
+			reported: []string{"A=3", "B=2"},
+		},
+		{
+			name:     "inherit allowlist",
+			req:      &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_INHERIT_ALLOWLIST, EnvAllowlist: []string{"GOPROC_TEST_L*"}, Env: []string{"A=1"}},
+			env:      []string{"GOPROC_TEST_LANG=C", "A=1"},
+			reported: []string{"GOPROC_TEST_LANG=" + redactedValue, "A=1"},
+		},
+		{
+			name:     "inherit and override",
+			req:      &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_INHERIT_AND_OVERRIDE, Env: []string{"GOPROC_TEST_LANG=en_US"}},
+			env:      []string{"GOPROC_TEST_HOME=/home/test", "GOPROC_TEST_LANG=en_US"},
+			reported: []string{"GOPROC_TEST_HOME=" + redactedValue, "GOPROC_TEST_LANG=en_US"},
+			inherits: true,
+		},
+		{
+			name:     "expand",
+			req:      &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_REPLACE, ExpandEnv: true, Env: []string{"DATA=${GOPROC_TEST_HOME}/data", "NONE=${GOPROC_TEST_UNSET}"}},
+			env:      []string{"DATA=/home/test/data", "NONE="},
+			reported: []string{"DATA=${GOPROC_TEST_HOME}/data", "NONE=${GOPROC_TEST_UNSET}"},
+		},
+		{
+			name:     "env file",
+			req:      &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_REPLACE, Cwd: dir, Env: []string{"TOKEN=plain"}, EnvFiles: map[string]string{"TOKEN": "token"}},
+			env:      []string{"TOKEN=s3cret"},
+			reported: []string{"TOKEN=" + redactedValue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, reported, err := composeEnv(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The server's environment is only checked for the test variables.
+			if tt.inherits {
+				env = slices.DeleteFunc(env, func(kv string) bool { return !slices.Contains(tt.env, kv) })
+				reported = slices.DeleteFunc(reported, func(kv string) bool { return !slices.Contains(tt.reported, kv) })
+			}
+
+			if !slices.Equal(env, tt.env) || (env == nil) != (tt.env == nil) {
+				t.Errorf("got env %q, want %q", env, tt.env)
+			}
+			if !slices.Equal(reported, tt.reported) {
+				t.Errorf("got reported env %q, want %q", reported, tt.reported)
+			}
+		})
+	}
+}
+
+func TestCheckEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *proto.ExecProcessRequest
+		ok   bool
+	}{
+		{name: "allowlist", req: &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_INHERIT_ALLOWLIST, EnvAllowlist: []string{"LC_*"}}, ok: true},
+		{name: "allowlist without its mode", req: &proto.ExecProcessRequest{EnvAllowlist: []string{"PATH"}}},
+		{name: "invalid allowlist", req: &proto.ExecProcessRequest{EnvMode: proto.EnvMode_ENV_MODE_INHERIT_ALLOWLIST, EnvAllowlist: []string{"["}}},
+		{name: "unknown mode", req: &proto.ExecProcessRequest{EnvMode: 42}},
+		{name: "invalid sensitive pattern", req: &proto.ExecProcessRequest{SensitiveEnv: []string{"["}}},
+		{name: "env file", req: &proto.ExecProcessRequest{EnvFiles: map[string]string{"TOKEN": "/run/secrets/token"}}, ok: true},
+		{name: "env file with invalid name", req: &proto.ExecProcessRequest{EnvFiles: map[string]string{"A=B": "/run/secrets/token"}}},
+		{name: "env file without path", req: &proto.ExecProcessRequest{EnvFiles: map[string]string{"TOKEN": ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEnv(tt.req)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidEnv) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidEnv)
+			}
+		})
+	}
+}
+
+func TestInheritedEnvNotReported(t *testing.T) {
+	t.Setenv("GOPROC_TEST_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	m := newTestManager(t, GoProcConfig{})
+
+	wait := true
+	res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{
+		Args:    []string{"sh", "-c", `test "$GOPROC_TEST_ACCESS_KEY_ID" = AKIAEXAMPLE`},
+		EnvMode: proto.EnvMode_ENV_MODE_INHERIT_AND_OVERRIDE,
+		Wait:    &wait,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != 0 {
+		t.Fatalf("process did not inherit the variable: exit code %d", res.ExitCode)
+	}
+
+	st, err := m.Status(context.Background(), res.PID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(st.Env, "GOPROC_TEST_ACCESS_KEY_ID="+redactedValue) {
+		t.Errorf("inherited value reported: %q", st.Env)
+	}
+}
@@ -5,5 +5,38 @@ import (
 )
 
 var (
-	ErrProcessNotFound = errors.New("process not found")
+	ErrProcessNotFound       = errors.New("process not found")
+	ErrProcessExited         = errors.New("process has already exited")
+	ErrTLSNotConfigured      = errors.New("tls certificate not configured and insecure mode not enabled")
+	ErrClientCANotSet        = errors.New("client certificate verification requires a CA file")
+	ErrInvalidCAFile         = errors.New("no certificates found in CA file")
+	ErrTransportNotChosen    = errors.New("no transport credentials configured, use WithTLS or WithInsecure")
+	ErrTokenFileNotSet       = errors.New("auth is enabled but no token file is configured")
+	ErrNoListeners           = errors.New("no tcp port or unix socket path configured")
+	ErrNotUnixSocket         = errors.New("path exists and is not a unix socket")
+	ErrPeerCredUnsupported   = errors.New("peer credentials are not supported on this platform")
+	ErrTCPDisabled           = errors.New("tcp listener is disabled")
+	ErrPolicyViolation       = errors.New("policy violation")
+	ErrNoCommand             = errors.New("no command given")
+	ErrExecutableNotFound    = errors.New("executable not found")
+	ErrAuditPathNotSet       = errors.New("audit is enabled but no path is configured")
+	ErrInvalidAuditBackups   = errors.New("audit.maxBackups must be at least 1 when the log is rotated")
+	ErrInvalidResumeToken    = errors.New("invalid resume token")
+	ErrResumeTokenExpired    = errors.New("resume token is no longer available, list processes to resync")
+	ErrSubscriberTooSlow     = errors.New("event stream fell behind, resume with the last resume token")
+	ErrStdinNotOpen          = errors.New("process stdin is not open")
+	ErrNoTerminal            = errors.New("process has no terminal")
+	ErrRegistryPathNotSet    = errors.New("registry is enabled but no path is configured")
+	ErrInvalidShutdownPolicy = errors.New("shutdown policy must be one of leave, terminate or kill")
+	ErrInitUnsupported       = errors.New("init mode is only supported on linux")
+	ErrDialTimeout           = errors.New("timed out connecting to server")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used for a different request")
+	ErrInvalidPipeline       = errors.New("invalid pipeline")
+	ErrInvalidOutputTarget   = errors.New("invalid output target")
+	ErrInvalidStdin          = errors.New("invalid stdin")
+	ErrInvalidEnv            = errors.New("invalid environment")
+	ErrInvalidLabels         = errors.New("invalid labels")
+	ErrInvalidSelector       = errors.New("invalid label selector")
+	ErrInvalidPageToken      = errors.New("invalid page token")
+	ErrServerShuttingDown    = errors.New("server is shutting down")
 )
@@ -0,0 +1,170 @@
+package goproc
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+const eventSubscriberBuffer = 256
+
+// eventBus fans process lifecycle events out to WatchEvents subscribers and
+// keeps the most recent ones so a reconnecting client can resume. Resume
+// tokens are "<epoch>-<seq>", where epoch identifies this server instance so
+// tokens from a previous run are rejected rather than silently misread.
+type eventBus struct {
+	mu          sync.Mutex
+	epoch       string
+	seq         uint64
+	history     []busEvent
+	historySize int
+	subscribers map[*eventSubscriber]struct{}
+}
+
+type busEvent struct {
+	seq uint64
+	ev  *proto.ProcessEvent
+}
+
+type eventSubscriber struct {
+	ch     chan *proto.ProcessEvent
+	pids   []int32
+	types  []proto.ProcessEventType
+	closed bool
+}
+
+func newEventBus(historySize int) *eventBus {
+	return &eventBus{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		historySize: historySize,
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+func (b *eventBus) publish(eventType proto.ProcessEventType, info *proto.ProcessInfo, signal int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	ev := &proto.ProcessEvent{
+		Type:         eventType,
+		Process:      info,
+		ResumeToken:  fmt.Sprintf("%s-%d", b.epoch, b.seq),
+		TimeUnixNano: time.Now().UnixNano(),
+		Signal:       signal,
+	}
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			b.history = b.history[1:]
+		}
+		b.history = append(b.history, busEvent{seq: b.seq, ev: ev})
+	}
+
+	for sub := range b.subscribers {
+		if !sub.matches(ev) {
+			continue
+		}
+
+		select {
+		case sub.ch <- ev:
+		default:
+			// The subscriber fell behind. Drop it so it can resume from its
+			// last token instead of blocking every other subscriber.
+			b.closeLocked(sub)
+		}
+	}
+}
+
+// subscribe registers a subscriber and returns the events it missed since
+// resumeToken, if one was given.
+func (b *eventBus) subscribe(pids []int32, types []proto.ProcessEventType, resumeToken string) (*eventSubscriber, []*proto.ProcessEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &eventSubscriber{
+		ch:    make(chan *proto.ProcessEvent, eventSubscriberBuffer),
+		pids:  pids,
+		types: types,
+	}
+
+	var backlog []*proto.ProcessEvent
+	if resumeToken != "" {
+		seq, err := b.parseToken(resumeToken)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if seq < b.seq {
+			oldest := b.seq + 1
+			if len(b.history) > 0 {
+				oldest = b.history[0].seq
+			}
+
+			if seq+1 < oldest {
+				return nil, nil, ErrResumeTokenExpired
+			}
+
+			for _, he := range b.history {
+				if he.seq > seq && sub.matches(he.ev) {
+					backlog = append(backlog, he.ev)
+				}
+			}
+		}
+	}
+
+	b.subscribers[sub] = struct{}{}
+	return sub, backlog, nil
+}
+
+func (b *eventBus) unsubscribe(sub *eventSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closeLocked(sub)
+}
+
+func (b *eventBus) closeLocked(sub *eventSubscriber) {
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	delete(b.subscribers, sub)
+	close(sub.ch)
+}
+
+func (b *eventBus) parseToken(token string) (uint64, error) {
+	epoch, seqStr, ok := strings.Cut(token, "-")
+	if !ok {
+		return 0, ErrInvalidResumeToken
+	}
+
+	if epoch != b.epoch {
+		return 0, ErrResumeTokenExpired
+	}
+
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || seq > b.seq {
+		return 0, ErrInvalidResumeToken
+	}
+
+	return seq, nil
+}
+
+func (s *eventSubscriber) matches(ev *proto.ProcessEvent) bool {
+	if len(s.types) > 0 && !slices.Contains(s.types, ev.Type) {
+		return false
+	}
+
+	if len(s.pids) > 0 && !slices.Contains(s.pids, ev.Process.GetPid()) {
+		return false
+	}
+
+	return true
+}
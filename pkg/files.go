@@ -0,0 +1,125 @@
+package goproc
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// execFiles are the files an exec request has the server open for the
+// process: its stdin file and the files its output is redirected to. They are
+// opened before the process may start and outside any lock, since opening a
+// FIFO blocks until something opens its other end. Paths are relative to cwd.
+// openExecFiles gives up on such an open once ctx is done.
+type execFiles struct {
+	stdin       *os.File
+	stdout      *os.File
+	stderr      *os.File
+	stageStderr []*os.File
+
+	closeOnce sync.Once
+}
+
+func openExecFiles(ctx context.Context, req *proto.ExecProcessRequest) (_ *execFiles, err error) {
+	files := &execFiles{}
+	defer func() {
+		if err != nil {
+			files.close()
+		}
+		// An open cut short by ctx is not the request's fault.
+		if err != nil && ctx.Err() != nil {
+			err = context.Cause(ctx)
+		}
+	}()
+
+	stdinFile := req.StdinFile
+	if req.Pipeline != nil && req.Pipeline.StdinFile != "" {
+		stdinFile = req.Pipeline.StdinFile
+	}
+	if stdinFile != "" {
+		if files.stdin, err = openFile(ctx, resolvePath(req.Cwd, stdinFile), os.O_RDONLY, 0); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidStdin, err)
+		}
+	}
+
+	if req.Stdout.GetType() == proto.OutputTargetType_OUTPUT_TARGET_FILE {
+		if files.stdout, err = openRedirect(ctx, req.Cwd, req.Stdout.File); err != nil {
+			return nil, err
+		}
+	}
+	if req.Stderr.GetType() == proto.OutputTargetType_OUTPUT_TARGET_FILE {
+		if files.stderr, err = openRedirect(ctx, req.Cwd, req.Stderr.File); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Pipeline == nil {
+		return files, nil
+	}
+
+	if req.Pipeline.Stdout != nil {
+		if files.stdout, err = openRedirect(ctx, req.Cwd, req.Pipeline.Stdout); err != nil {
+			return nil, err
+		}
+	}
+
+	files.stageStderr = make([]*os.File, len(req.Pipeline.Stages))
+	for i, stage := range req.Pipeline.Stages {
+		if stage.Stderr == nil {
+			continue
+		}
+		if files.stageStderr[i], err = openRedirect(ctx, req.Cwd, stage.Stderr); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// close closes the files, which the process has its own copies of once it has
+// started. It is safe to call more than once and on nil.
+func (f *execFiles) close() {
+	if f == nil {
+		return
+	}
+
+	f.closeOnce.Do(func() {
+		for _, file := range append([]*os.File{f.stdin, f.stdout, f.stderr}, f.stageStderr...) {
+			if file != nil {
+				file.Close()
+			}
+		}
+	})
+}
+
+// openFile opens a file like os.OpenFile, but returns once ctx is done even if
+// the open blocks, as it does on a FIFO nobody has opened the other end of.
+// The file is then closed if the open ever completes.
+func openFile(ctx context.Context, name string, flag int, perm fs.FileMode) (*os.File, error) {
+	type result struct {
+		f   *os.File
+		err error
+	}
+
+	opened := make(chan result, 1)
+	go func() {
+		f, err := os.OpenFile(name, flag, perm)
+		opened <- result{f, err}
+	}()
+
+	select {
+	case r := <-opened:
+		return r.f, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-opened; r.f != nil {
+				r.f.Close()
+			}
+		}()
+		return nil, context.Cause(ctx)
+	}
+}
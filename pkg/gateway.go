@@ -0,0 +1,563 @@
+package goproc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	gatewayMaxBodyBytes = 16 << 20
+	lastEventIDHeader   = "Last-Event-ID"
+	resumeTokenField    = "resume_token"
+)
+
+// gatewayRoutes maps HTTP endpoints to GoProc RPCs. Path parameters, and query
+// parameters of GET requests, are copied into request fields of the same name;
+// POST bodies are decoded as the JSON form of the request message.
+// Server-streaming RPCs are served as server-sent events and bidirectional
+// ones over a WebSocket. ExecUpload is gRPC only: HTTP callers send stdin with
+// Exec's stdin_data.
+var gatewayRoutes = []struct {
+	pattern string
+	rpc     string
+}{
+	{"POST /v1/processes", "Exec"},
+	{"GET /v1/processes", "ListProcesses"},
+	{"POST /v1/processes/kill", "KillMatching"},
+	{"POST /v1/processes/signal", "SignalMatching"},
+	{"GET /v1/processes/wait", "WaitAll"},
+	{"GET /v1/processes/{pid}", "Status"},
+	{"GET /v1/processes/{pid}/wait", "Wait"},
+	{"POST /v1/processes/{pid}/kill", "Kill"},
+	{"POST /v1/processes/{pid}/signal", "Signal"},
+	{"GET /v1/processes/{pid}/stdout", "Stdout"},
+	{"GET /v1/processes/{pid}/stderr", "Stderr"},
+	{"GET /v1/processes/{pid}/output", "StreamOutput"},
+	{"GET /v1/processes/{pid}/attach", "Attach"},
+	{"GET /v1/events", "WatchEvents"},
+}
+
+var (
+	pathParamPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+	gatewayMarshal   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	gatewayUnmarshal = protojson.UnmarshalOptions{}
+)
+
+// gateway serves the GoProc service as JSON over HTTP. Calls go through the
+// generated gRPC handlers and the same interceptors as the gRPC listener, so
+// authentication, audit and metrics apply to HTTP callers as well.
+type gateway struct {
+	cs          *GoProcServer
+	unary       grpc.UnaryServerInterceptor
+	stream      grpc.StreamServerInterceptor
+	tlsConfig   *tls.Config
+	checkOrigin func(*http.Request) bool
+	upgrader    *websocket.Upgrader
+}
+
+func newGateway(cs *GoProcServer, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) (*gateway, error) {
+	tlsConfig, err := serverTLSConfig(cs.cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	checkOrigin := originChecker(cs.cfg.Gateway.AllowedOrigins)
+
+	return &gateway{
+		cs:          cs,
+		unary:       chainUnaryInterceptors(unary),
+		stream:      chainStreamInterceptors(stream),
+		tlsConfig:   tlsConfig,
+		checkOrigin: checkOrigin,
+		upgrader:    &websocket.Upgrader{CheckOrigin: checkOrigin},
+	}, nil
+}
+
+// originChecker accepts requests without an Origin, from the gateway's own
+// origin, or from one of allowedOrigins, where "*" allows any origin.
+func originChecker(allowedOrigins []string) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || slices.Contains(allowedOrigins, "*") || slices.Contains(allowedOrigins, origin) {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+func (g *gateway) handler() http.Handler {
+	methods := make(map[string]grpc.MethodDesc)
+	for _, md := range proto.GoProc_ServiceDesc.Methods {
+		methods[md.MethodName] = md
+	}
+
+	streams := make(map[string]grpc.StreamDesc)
+	for _, sd := range proto.GoProc_ServiceDesc.Streams {
+		streams[sd.StreamName] = sd
+	}
+
+	mux := http.NewServeMux()
+	for _, route := range gatewayRoutes {
+		if md, ok := methods[route.rpc]; ok {
+			mux.HandleFunc(route.pattern, g.guard(g.unaryHandler(route.pattern, md)))
+		} else if sd, ok := streams[route.rpc]; ok && sd.ClientStreams {
+			mux.HandleFunc(route.pattern, g.guard(g.websocketHandler(route.pattern, sd)))
+		} else if ok {
+			mux.HandleFunc(route.pattern, g.guard(g.streamHandler(route.pattern, sd)))
+		}
+	}
+
+	return mux
+}
+
+// guard rejects requests a web page on another site could send on behalf of a
+// browser: requests from an origin that is not allowed, requests other than
+// GET with query parameters, and POSTs whose body is not declared as JSON,
+// which browsers only send cross-site after a CORS preflight.
+func (g *gateway) guard(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !g.checkOrigin(r) {
+			writeGatewayError(w, status.Errorf(codes.PermissionDenied, "origin %q is not allowed", r.Header.Get("Origin")))
+			return
+		}
+
+		if r.Method != http.MethodGet && r.URL.RawQuery != "" {
+			writeGatewayError(w, status.Error(codes.InvalidArgument, "query parameters are only accepted on GET requests"))
+			return
+		}
+
+		if r.Method == http.MethodPost && !isJSONContentType(r.Header.Get("Content-Type")) {
+			writeGatewayStatus(w, http.StatusUnsupportedMediaType, status.Error(codes.InvalidArgument, "Content-Type must be application/json"))
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+func (g *gateway) serve(ctx context.Context, port uint) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           g.handler(),
+		TLSConfig:         g.tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	log.Info().Msgf("Serving HTTP gateway @%s", srv.Addr)
+
+	var err error
+	if g.tlsConfig != nil {
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		err = srv.ListenAndServe()
+	}
+
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+func (g *gateway) unaryHandler(pattern string, desc grpc.MethodDesc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dec := func(v any) error {
+			return decodeGatewayRequest(r, pattern, v.(protobuf.Message))
+		}
+
+		resp, err := desc.Handler(g.cs, g.incomingContext(r), dec, g.unary)
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+
+		body, err := gatewayMarshal.Marshal(resp.(protobuf.Message))
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+func (g *gateway) streamHandler(pattern string, desc grpc.StreamDesc) http.HandlerFunc {
+	fullMethod := fmt.Sprintf("/%s/%s", proto.GoProc_ServiceDesc.ServiceName, desc.StreamName)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		stream := &sseServerStream{
+			ctx:     g.incomingContext(r),
+			w:       w,
+			r:       r,
+			pattern: pattern,
+		}
+
+		info := &grpc.StreamServerInfo{
+			FullMethod:     fullMethod,
+			IsServerStream: desc.ServerStreams,
+			IsClientStream: desc.ClientStreams,
+		}
+
+		err := g.stream(g.cs, stream, info, desc.Handler)
+		if err != nil && !stream.started {
+			writeGatewayError(w, err)
+			return
+		}
+
+		if err != nil {
+			stream.writeEvent("error", "", statusJSON(err))
+		}
+	}
+}
+
+// incomingContext makes an HTTP request look like an incoming gRPC call to the
+// interceptors: the bearer token and protocol version go into the metadata and
+// the remote address into the peer.
+func (g *gateway) incomingContext(r *http.Request) context.Context {
+	md := metadata.Pairs(protocolVersionHeader, strconv.Itoa(ProtocolVersion))
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		md.Set(authorizationHeader, auth)
+	}
+
+	p := &peer.Peer{Addr: gatewayAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State:          *r.TLS,
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}
+	}
+
+	return peer.NewContext(metadata.NewIncomingContext(r.Context(), md), p)
+}
+
+// decodeGatewayRequest fills msg from the request body, the path parameters of
+// pattern and, for GET requests, the query string.
+func decodeGatewayRequest(r *http.Request, pattern string, msg protobuf.Message) error {
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, gatewayMaxBodyBytes))
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if len(strings.TrimSpace(string(body))) > 0 {
+			if err := gatewayUnmarshal.Unmarshal(body, msg); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+			}
+		}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(pattern, -1) {
+		if err := setRequestField(msg, match[1], r.PathValue(match[1])); err != nil {
+			return err
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		for name, values := range r.URL.Query() {
+			if err := setRequestField(msg, name, values...); err != nil {
+				return err
+			}
+		}
+	}
+
+	if id := r.Header.Get(lastEventIDHeader); id != "" && hasField(msg, resumeTokenField) {
+		if err := setRequestField(msg, resumeTokenField, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasField(msg protobuf.Message, name string) bool {
+	return msg.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name)) != nil
+}
+
+// setRequestField sets a scalar or repeated scalar field by its proto or JSON
+// name from string values.
+func setRequestField(msg protobuf.Message, name string, values ...string) error {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	fd := fields.ByName(protoreflect.Name(name))
+	if fd == nil {
+		fd = fields.ByJSONName(name)
+	}
+	if fd == nil || fd.IsMap() || fd.Message() != nil {
+		return status.Errorf(codes.InvalidArgument, "unknown parameter %q", name)
+	}
+
+	if fd.IsList() {
+		list := m.Mutable(fd).List()
+		for _, s := range values {
+			v, err := parseFieldValue(fd, s)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+
+		return nil
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	v, err := parseFieldValue(fd, values[len(values)-1])
+	if err != nil {
+		return err
+	}
+
+	m.Set(fd, v)
+	return nil
+}
+
+func parseFieldValue(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, status.Errorf(codes.InvalidArgument, "invalid value %q for %s: %v", s, fd.Name(), err)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	}
+
+	return invalid(fmt.Errorf("unsupported field kind %s", fd.Kind()))
+}
+
+func writeGatewayError(w http.ResponseWriter, err error) {
+	writeGatewayStatus(w, httpStatusFromCode(status.Code(err)), err)
+}
+
+func writeGatewayStatus(w http.ResponseWriter, httpStatus int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(statusJSON(err))
+}
+
+func statusJSON(err error) []byte {
+	body, marshalErr := gatewayMarshal.Marshal(status.Convert(err).Proto())
+	if marshalErr != nil {
+		return []byte(`{"code":13,"message":"failed to encode error"}`)
+	}
+
+	return body
+}
+
+// httpStatusFromCode maps gRPC codes to HTTP statuses the same way grpc-gateway
+// does, so HTTP clients can rely on familiar semantics.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
+
+// sseServerStream adapts an HTTP response to grpc.ServerStream, sending each
+// message as a server-sent event. Messages with a resume_token use it as the
+// event id, so browsers resume automatically through Last-Event-ID.
+type sseServerStream struct {
+	ctx      context.Context
+	w        http.ResponseWriter
+	r        *http.Request
+	pattern  string
+	received bool
+	started  bool
+}
+
+func (s *sseServerStream) SetHeader(metadata.MD) error  { return nil }
+func (s *sseServerStream) SendHeader(metadata.MD) error { return nil }
+func (s *sseServerStream) SetTrailer(metadata.MD)       {}
+
+func (s *sseServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sseServerStream) RecvMsg(m any) error {
+	if s.received {
+		return io.EOF
+	}
+
+	s.received = true
+	return decodeGatewayRequest(s.r, s.pattern, m.(protobuf.Message))
+}
+
+func (s *sseServerStream) SendMsg(m any) error {
+	msg := m.(protobuf.Message)
+
+	data, err := gatewayMarshal.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	id := ""
+	if hasField(msg, resumeTokenField) {
+		fd := msg.ProtoReflect().Descriptor().Fields().ByName(resumeTokenField)
+		id = msg.ProtoReflect().Get(fd).String()
+	}
+
+	return s.writeEvent("", id, data)
+}
+
+func (s *sseServerStream) writeEvent(event, id string, data []byte) error {
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+	}
+
+	var b strings.Builder
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	fmt.Fprintf(&b, "data: %s\n\n", data)
+
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return err
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// gatewayAddr is the remote address of an HTTP caller.
+type gatewayAddr string
+
+func (a gatewayAddr) Network() string { return "tcp" }
+func (a gatewayAddr) String() string  { return string(a) }
+
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func chainStreamInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+
+		return next(srv, ss)
+	}
+}
@@ -0,0 +1,75 @@
+package goproc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGatewayRejectsCrossSiteRequests(t *testing.T) {
+	cs := newTestServer(t, GoProcConfig{
+		TLS:     TLSConfig{Insecure: true},
+		Gateway: GatewayConfig{AllowedOrigins: []string{"https://dashboard.example"}},
+	})
+	unary, stream, closeInterceptors, err := cs.interceptors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeInterceptors()
+
+	g, err := newGateway(cs, unary, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(g.handler())
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		origin      string
+		body        string
+		status      int
+	}{
+		{name: "exec", method: http.MethodPost, path: "/v1/processes", contentType: "application/json", body: `{"args": ["true"]}`, status: http.StatusOK},
+		{name: "exec with charset", method: http.MethodPost, path: "/v1/processes", contentType: "application/json; charset=utf-8", body: `{"args": ["true"]}`, status: http.StatusOK},
+		{name: "exec from own origin", method: http.MethodPost, path: "/v1/processes", contentType: "application/json", origin: srv.URL, body: `{"args": ["true"]}`, status: http.StatusOK},
+		{name: "exec from allowed origin", method: http.MethodPost, path: "/v1/processes", contentType: "application/json", origin: "https://dashboard.example", body: `{"args": ["true"]}`, status: http.StatusOK},
+		{name: "exec from other origin", method: http.MethodPost, path: "/v1/processes", contentType: "application/json", origin: "https://evil.example", body: `{"args": ["true"]}`, status: http.StatusForbidden},
+		{name: "exec from query", method: http.MethodPost, path: "/v1/processes?args=true", contentType: "application/json", status: http.StatusBadRequest},
+		{name: "exec as form", method: http.MethodPost, path: "/v1/processes", contentType: "application/x-www-form-urlencoded", body: `{"args": ["true"]}`, status: http.StatusUnsupportedMediaType},
+		{name: "exec as text", method: http.MethodPost, path: "/v1/processes", contentType: "text/plain", body: `{"args": ["true"]}`, status: http.StatusUnsupportedMediaType},
+		{name: "exec without content type", method: http.MethodPost, path: "/v1/processes", body: `{"args": ["true"]}`, status: http.StatusUnsupportedMediaType},
+		{name: "list with query", method: http.MethodGet, path: "/v1/processes?state=PROCESS_STATE_RUNNING", status: http.StatusOK},
+		{name: "list from other origin", method: http.MethodGet, path: "/v1/processes", origin: "https://evil.example", status: http.StatusForbidden},
+		{name: "attach without handshake", method: http.MethodGet, path: "/v1/processes/1/attach?stdin=aGk=", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("got HTTP %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
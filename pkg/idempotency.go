@@ -0,0 +1,110 @@
+package goproc
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// execDeduper makes Exec idempotent for requests carrying an idempotency key:
+// the first request with a key runs, and repeats within the window get its
+// result, waiting for it if it is still in flight. Failed requests are
+// forgotten so that they can be retried.
+type execDeduper struct {
+	window  time.Duration
+	mu      sync.Mutex
+	entries map[string]*execEntry
+}
+
+type execEntry struct {
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	res         *ExecResult
+	err         error
+	expires     time.Time
+}
+
+func newExecDeduper(window time.Duration) *execDeduper {
+	return &execDeduper{window: window, entries: make(map[string]*execEntry)}
+}
+
+func (d *execDeduper) do(ctx context.Context, req *proto.ExecProcessRequest, exec func() (*ExecResult, error)) (*ExecResult, error) {
+	if req.IdempotencyKey == "" || d.window <= 0 {
+		return exec()
+	}
+
+	// Keys are scoped to the caller so that callers can't collide.
+	key := callerFromContext(ctx) + "\x00" + req.IdempotencyKey
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.expire(time.Now())
+
+	if e, ok := d.entries[key]; ok {
+		d.mu.Unlock()
+
+		if e.fingerprint != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if e.err != nil {
+			return e.res, e.err
+		}
+
+		res := *e.res
+		return &res, nil
+	}
+
+	e := &execEntry{fingerprint: fingerprint, done: make(chan struct{})}
+	d.entries[key] = e
+	d.mu.Unlock()
+
+	e.res, e.err = exec()
+
+	d.mu.Lock()
+	if e.err != nil {
+		delete(d.entries, key)
+	} else {
+		e.expires = time.Now().Add(d.window)
+	}
+	d.mu.Unlock()
+
+	close(e.done)
+	return e.res, e.err
+}
+
+// expire drops completed entries older than the window. The caller holds d.mu.
+func (d *execDeduper) expire(now time.Time) {
+	for key, e := range d.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(d.entries, key)
+		}
+	}
+}
+
+// requestFingerprint hashes everything in the request but the key itself, to
+// detect a key reused for a different request.
+func requestFingerprint(req *proto.ExecProcessRequest) ([sha256.Size]byte, error) {
+	req = protobuf.Clone(req).(*proto.ExecProcessRequest)
+	req.IdempotencyKey = ""
+
+	data, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(data), nil
+}
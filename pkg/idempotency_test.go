@@ -0,0 +1,64 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExecDeduperDuplicateGivesUpWithContext(t *testing.T) {
+	d := newExecDeduper(time.Minute)
+	req := &proto.ExecProcessRequest{Args: []string{"true"}, IdempotencyKey: "k"}
+
+	release := make(chan struct{})
+	first := make(chan error, 1)
+	go func() {
+		_, err := d.do(context.Background(), req, func() (*ExecResult, error) {
+			<-release
+			return &ExecResult{PID: 1}, nil
+		})
+		first <- err
+	}()
+
+	// Wait for the first request to be in flight so the second one waits on it.
+	for {
+		d.mu.Lock()
+		n := len(d.entries)
+		d.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := d.do(ctx, req, func() (*ExecResult, error) {
+		t.Error("ran a duplicate request")
+		return nil, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if code := status.Code(statusError(err, 0)); code != codes.DeadlineExceeded {
+		t.Errorf("got code %v, want %v", code, codes.DeadlineExceeded)
+	}
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := d.do(context.Background(), req, func() (*ExecResult, error) {
+		t.Error("ran a duplicate request")
+		return nil, nil
+	})
+	if err != nil || res.PID != 1 {
+		t.Errorf("got %+v, %v, want the first request's result", res, err)
+	}
+}
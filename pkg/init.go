@@ -0,0 +1,103 @@
+package goproc
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// reaper collects every child of the server in init mode, including orphaned
+// grandchildren re-parented to it. Processes are started under its lock and
+// registered, so when it reaps one before the process's own cmd.Wait does,
+// the exit status is handed over instead of lost.
+type reaper struct {
+	mu      sync.Mutex
+	managed map[int]chan syscall.WaitStatus
+}
+
+func newReaper() *reaper {
+	return &reaper{managed: make(map[int]chan syscall.WaitStatus)}
+}
+
+func (r *reaper) start(p *Process) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := p.start(); err != nil {
+		return err
+	}
+
+	p.reaped = make(map[int]chan syscall.WaitStatus)
+	for _, cmd := range p.commands() {
+		ch := make(chan syscall.WaitStatus, 1)
+		p.reaped[cmd.Process.Pid] = ch
+		r.managed[cmd.Process.Pid] = ch
+	}
+
+	return nil
+}
+
+// wait returns the exit status of the command of p with pid once the reaper
+// has collected it.
+func (r *reaper) wait(p *Process, pid int) syscall.WaitStatus {
+	ws := <-p.reaped[pid]
+	r.forget(p, pid)
+	return ws
+}
+
+// forget unregisters the command of p with pid, unless the pid has already
+// been reused by a newer process.
+func (r *reaper) forget(p *Process, pid int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.managed[pid] == p.reaped[pid] {
+		delete(r.managed, pid)
+	}
+}
+
+// collected hands the status of a reaped child to its process, reporting
+// whether it was a managed one. The caller holds r.mu.
+func (r *reaper) collected(pid int, ws syscall.WaitStatus) bool {
+	ch, ok := r.managed[pid]
+	if ok {
+		ch <- ws
+		delete(r.managed, pid)
+	}
+
+	return ok
+}
+
+// forwardSignals relays sigs received by the server to every running process
+// until ctx is done.
+func (m *Manager) forwardSignals(ctx context.Context, sigs []os.Signal) {
+	if len(sigs) == 0 {
+		return
+	}
+
+	ch := make(chan os.Signal, len(sigs))
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			for _, proc := range m.runningProcesses() {
+				err := proc.Signal(sig)
+				if err != nil {
+					log.Debug().Err(err).Int("pid", proc.pid).Msgf("Failed to forward %v", sig)
+					continue
+				}
+
+				m.events.publish(proto.ProcessEventType_PROCESS_EVENT_SIGNALED, processInfo(proc), int32(sig.(syscall.Signal)))
+			}
+		}
+	}
+}
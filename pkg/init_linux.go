@@ -0,0 +1,96 @@
+//go:build linux
+
+package goproc
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sys/unix"
+)
+
+// reapInterval is how often children are collected even without a SIGCHLD,
+// since signals are coalesced.
+const reapInterval = time.Second
+
+// startReaper becomes a child subreaper, unless already PID 1, and starts
+// reaping children in the background.
+func startReaper() (*reaper, error) {
+	if os.Getpid() != 1 {
+		if err := unix.Prctl(unix.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0); err != nil {
+			return nil, fmt.Errorf("failed to become a child subreaper: %w", err)
+		}
+	}
+
+	r := newReaper()
+
+	sigchld := make(chan os.Signal, 1)
+	signal.Notify(sigchld, syscall.SIGCHLD)
+
+	go func() {
+		ticker := time.NewTicker(reapInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-sigchld:
+			case <-ticker.C:
+			}
+
+			r.reap()
+		}
+	}()
+
+	return r, nil
+}
+
+func (r *reaper) reap() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		var ws syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &ws, syscall.WNOHANG, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || pid <= 0 {
+			return
+		}
+
+		if !r.collected(pid, ws) {
+			log.Debug().Int("pid", pid).Msg("Reaped orphaned process")
+		}
+	}
+}
+
+// parseSignals parses signal names such as "SIGHUP" or "HUP", or numbers.
+func parseSignals(names []string) ([]os.Signal, error) {
+	sigs := make([]os.Signal, 0, len(names))
+	for _, name := range names {
+		if n, err := strconv.Atoi(name); err == nil {
+			sigs = append(sigs, syscall.Signal(n))
+			continue
+		}
+
+		name = strings.ToUpper(name)
+		if !strings.HasPrefix(name, "SIG") {
+			name = "SIG" + name
+		}
+
+		sig := unix.SignalNum(name)
+		if sig == 0 {
+			return nil, fmt.Errorf("unknown signal %q", name)
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	return sigs, nil
+}
@@ -0,0 +1,120 @@
+package goproc
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// The reaper collects every child of the process it runs in for good, so the
+// init mode tests run in a test binary of their own.
+const initModeEnv = "GOPROC_TEST_INIT_MODE"
+
+func runInInitMode(t *testing.T) bool {
+	t.Helper()
+
+	if os.Getenv(initModeEnv) == "1" {
+		return true
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^"+t.Name()+"$", "-test.v")
+	cmd.Env = append(os.Environ(), initModeEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+
+	return false
+}
+
+func TestInitModeWait(t *testing.T) {
+	if !runInInitMode(t) {
+		return
+	}
+
+	m := newTestManager(t, GoProcConfig{Init: InitConfig{Enabled: true}})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		args     []string
+		kill     bool
+		exitCode int
+	}{
+		{name: "exit status", args: []string{"sh", "-c", "exit 3"}, exitCode: 3},
+		{name: "success", args: []string{"true"}, exitCode: 0},
+		{name: "killed", args: []string{"sleep", "60"}, kill: true, exitCode: 128 + int(syscall.SIGKILL)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Either the reaper or the process's own wait collects the exit
+			// status first, so run each case enough times to see both.
+			for i := 0; i < 20; i++ {
+				res, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: tt.args})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tt.kill {
+					if err := m.Kill(ctx, res.PID); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				wres, err := m.Wait(ctx, res.PID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if wres.ExitCode != tt.exitCode {
+					t.Fatalf("run %d: got exit code %d, want %d", i, wres.ExitCode, tt.exitCode)
+				}
+			}
+		})
+	}
+}
+
+func TestInitModeReapsOrphans(t *testing.T) {
+	if !runInInitMode(t) {
+		return
+	}
+
+	m := newTestManager(t, GoProcConfig{Init: InitConfig{Enabled: true}})
+	ctx := context.Background()
+
+	// The shell exits at once, leaving its background sleep to the server.
+	res, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"sh", "-c", "sleep 0.2 >/dev/null & echo $!"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Wait(ctx, res.PID); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := m.Stdout(ctx, res.PID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphan, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		t.Fatalf("got stdout %q, want the orphan's pid", out)
+	}
+
+	// A zombie keeps its /proc entry until it is reaped.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat("/proc/" + strconv.Itoa(orphan)); os.IsNotExist(err) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("orphan %d was not reaped", orphan)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
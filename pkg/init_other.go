@@ -0,0 +1,15 @@
+//go:build !linux
+
+package goproc
+
+import (
+	"os"
+)
+
+func startReaper() (*reaper, error) {
+	return nil, ErrInitUnsupported
+}
+
+func parseSignals(names []string) ([]os.Signal, error) {
+	return nil, ErrInitUnsupported
+}
@@ -0,0 +1,98 @@
+package goproc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// uploadChunkSize is the size of the stdin chunks ExecUpload copies to the
+// process and GoProcClient sends to the server.
+const uploadChunkSize = 32 << 10
+
+// checkStdin checks that an exec request gives the process at most one stdin.
+// upload is set for ExecUpload requests, whose stdin is the uploaded stream.
+func checkStdin(req *proto.ExecProcessRequest, upload bool) error {
+	var sources []string
+	if upload {
+		sources = append(sources, "upload")
+	}
+	if req.Stdin {
+		sources = append(sources, "stdin")
+	}
+	if len(req.StdinData) > 0 {
+		sources = append(sources, "stdin_data")
+	}
+	if req.StdinFile != "" {
+		sources = append(sources, "stdin_file")
+	}
+	if req.Pipeline != nil && req.Pipeline.StdinFile != "" {
+		sources = append(sources, "pipeline.stdin_file")
+	}
+
+	switch {
+	case len(sources) > 1:
+		return fmt.Errorf("%w: %s and %s are mutually exclusive", ErrInvalidStdin, sources[0], sources[1])
+	case req.Tty && len(sources) == 1 && sources[0] != "stdin":
+		return fmt.Errorf("%w: %s can't be used with tty", ErrInvalidStdin, sources[0])
+	}
+
+	return nil
+}
+
+// openInput returns what the process reads as stdin when it is not kept open:
+// the inline data, the stdin file, or nil for /dev/null.
+func (p *Process) openInput() io.Reader {
+	switch {
+	case len(p.stdinData) > 0:
+		return bytes.NewReader(p.stdinData)
+	case p.files != nil && p.files.stdin != nil:
+		return p.files.stdin
+	}
+
+	return nil
+}
+
+// uploadStdin copies r to the stdin of proc and closes it at end-of-file. If
+// the process exits first the rest of r is left unread. If reading r fails or
+// ctx is done the process is killed rather than left to run on truncated
+// input.
+func uploadStdin(ctx context.Context, proc *Process, r io.Reader) error {
+	fail := func(err error) error {
+		proc.Kill()
+		return err
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, writeErr := proc.WriteStdin(buf[:n]); errors.Is(writeErr, ErrProcessExited) {
+				return nil
+			} else if writeErr != nil {
+				return fail(writeErr)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := proc.CloseStdin(); err != nil && !errors.Is(err, ErrProcessExited) {
+		return err
+	}
+
+	return nil
+}
@@ -5,27 +5,111 @@ import (
 	"sync"
 )
 
+// outputChunkSize caps the data a subscriber reads from a SafeBuffer at once,
+// so a follower catching up doesn't get one oversized message.
+const outputChunkSize = 32 << 10
+
 type SafeBuffer struct {
 	mu  sync.Mutex
 	buf bytes.Buffer
+	// base is the position in the stream of the first byte in buf. Bytes
+	// before it were taken by StringAndReset.
+	base        int64
+	subscribers map[*BufferSubscription]struct{}
 }
 
 func (b *SafeBuffer) Write(p []byte) (int, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+
+	// Never block the process on a slow reader; subscribers read the data
+	// from the buffer when they get to it.
+	for sub := range b.subscribers {
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+
 	return b.buf.Write(p)
 }
 
+// BufferSubscription follows the writes to a SafeBuffer. A subscriber that
+// falls behind catches up from the buffer rather than being dropped.
+type BufferSubscription struct {
+	b      *SafeBuffer
+	pos    int64
+	notify chan struct{}
+}
+
+// Subscribe returns the data buffered so far, without consuming it, and a
+// subscription to every later write. Cancel must be called once the caller
+// stops reading.
+func (b *SafeBuffer) Subscribe() (snapshot []byte, sub *BufferSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = make(map[*BufferSubscription]struct{})
+	}
+
+	sub = &BufferSubscription{b: b, pos: b.base + int64(b.buf.Len()), notify: make(chan struct{}, 1)}
+	b.subscribers[sub] = struct{}{}
+
+	return bytes.Clone(b.buf.Bytes()), sub
+}
+
+// Ready receives a value when data may have been written since the last Next.
+func (s *BufferSubscription) Ready() <-chan struct{} {
+	return s.notify
+}
+
+// Next returns up to outputChunkSize bytes written since the last call, and
+// how many bytes before them were taken by StringAndReset before the
+// subscriber read them. Both are zero once the subscriber is up to date.
+func (s *BufferSubscription) Next() (data []byte, skipped int64) {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+
+	if s.pos < s.b.base {
+		skipped = s.b.base - s.pos
+		s.pos = s.b.base
+	}
+
+	unread := s.b.buf.Bytes()[s.pos-s.b.base:]
+	if len(unread) > outputChunkSize {
+		unread = unread[:outputChunkSize]
+	}
+	s.pos += int64(len(unread))
+
+	return bytes.Clone(unread), skipped
+}
+
+// Cancel ends the subscription.
+func (s *BufferSubscription) Cancel() {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+
+	delete(s.b.subscribers, s)
+}
+
 func (b *SafeBuffer) String() string {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	return b.buf.String()
 }
 
+func (b *SafeBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
 func (b *SafeBuffer) StringAndReset() string {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	s := b.buf.String()
+	b.base += int64(b.buf.Len())
 	b.buf.Reset()
 	return s
 }
@@ -0,0 +1,72 @@
+package goproc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBufferSubscription(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   string
+		writes   []string
+		reset    bool
+		after    []string
+		snapshot string
+		data     string
+		skipped  int64
+	}{
+		{name: "nothing written", before: "old", snapshot: "old"},
+		{name: "later writes", before: "old", writes: []string{"a", "b"}, snapshot: "old", data: "ab"},
+		{name: "more writes than a channel holds", writes: strings.Split(strings.Repeat("x", 10000), ""), data: strings.Repeat("x", 10000)},
+		{name: "write larger than a chunk", writes: []string{strings.Repeat("y", 3*outputChunkSize+1)}, data: strings.Repeat("y", 3*outputChunkSize+1)},
+		{name: "unread output taken", before: "old", writes: []string{"abc"}, reset: true, after: []string{"de"}, snapshot: "old", data: "de", skipped: 3},
+		{name: "read output taken", before: "old", reset: true, after: []string{"de"}, snapshot: "old", data: "de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &SafeBuffer{}
+			b.Write([]byte(tt.before))
+
+			snapshot, sub := b.Subscribe()
+			defer sub.Cancel()
+
+			for _, w := range tt.writes {
+				b.Write([]byte(w))
+			}
+			if tt.reset {
+				b.StringAndReset()
+			}
+			for _, w := range tt.after {
+				b.Write([]byte(w))
+			}
+
+			if string(snapshot) != tt.snapshot {
+				t.Errorf("got snapshot %q, want %q", snapshot, tt.snapshot)
+			}
+
+			var data bytes.Buffer
+			var skipped int64
+			for {
+				chunk, n := sub.Next()
+				if len(chunk) == 0 && n == 0 {
+					break
+				}
+				if len(chunk) > outputChunkSize {
+					t.Fatalf("got a %d byte chunk, want at most %d", len(chunk), outputChunkSize)
+				}
+				data.Write(chunk)
+				skipped += n
+			}
+
+			if data.String() != tt.data {
+				t.Errorf("got %d bytes of data, want %d", data.Len(), len(tt.data))
+			}
+			if skipped != tt.skipped {
+				t.Errorf("got %d skipped bytes, want %d", skipped, tt.skipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package goproc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+)
+
+// checkLabels checks the labels of an exec request.
+func checkLabels(labels map[string]string) error {
+	for _, key := range sortedKeys(labels) {
+		if !labelKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: invalid key %q", ErrInvalidLabels, key)
+		}
+		if !labelValuePattern.MatchString(labels[key]) {
+			return fmt.Errorf("%w: invalid value %q for %s", ErrInvalidLabels, labels[key], key)
+		}
+	}
+
+	return nil
+}
+
+type selectorOp int
+
+const (
+	selectorEquals selectorOp = iota
+	selectorNotEquals
+	selectorExists
+	selectorNotExists
+)
+
+type labelRequirement struct {
+	key   string
+	op    selectorOp
+	value string
+}
+
+// labelSelector matches the labels of processes. Every requirement has to
+// hold; an empty selector matches every process.
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses comma-separated requirements: "key=value" or
+// "key==value", "key!=value", "key" and "!key".
+func parseLabelSelector(s string) (labelSelector, error) {
+	var sel labelSelector
+	if strings.TrimSpace(s) == "" {
+		return sel, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		var req labelRequirement
+		switch {
+		case strings.HasPrefix(part, "!"):
+			req = labelRequirement{key: strings.TrimSpace(part[1:]), op: selectorNotExists}
+		case strings.Contains(part, "!="):
+			key, value, _ := strings.Cut(part, "!=")
+			req = labelRequirement{key: strings.TrimSpace(key), op: selectorNotEquals, value: strings.TrimSpace(value)}
+		case strings.Contains(part, "="):
+			key, value, _ := strings.Cut(part, "=")
+			value = strings.TrimPrefix(value, "=")
+			req = labelRequirement{key: strings.TrimSpace(key), op: selectorEquals, value: strings.TrimSpace(value)}
+		default:
+			req = labelRequirement{key: part, op: selectorExists}
+		}
+
+		if !labelKeyPattern.MatchString(req.key) {
+			return nil, fmt.Errorf("%w: invalid key in %q", ErrInvalidSelector, part)
+		}
+		if !labelValuePattern.MatchString(req.value) {
+			return nil, fmt.Errorf("%w: invalid value in %q", ErrInvalidSelector, part)
+		}
+
+		sel = append(sel, req)
+	}
+
+	return sel, nil
+}
+
+func (sel labelSelector) matches(labels map[string]string) bool {
+	for _, req := range sel {
+		value, ok := labels[req.key]
+
+		var match bool
+		switch req.op {
+		case selectorEquals:
+			match = ok && value == req.value
+		case selectorNotEquals:
+			match = !ok || value != req.value
+		case selectorExists:
+			match = ok
+		case selectorNotExists:
+			match = !ok
+		}
+
+		if !match {
+			return false
+		}
+	}
+
+	return true
+}
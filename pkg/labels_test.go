@@ -0,0 +1,111 @@
+package goproc
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     labelSelector
+		err      bool
+	}{
+		{selector: ""},
+		{selector: "  "},
+		{selector: "app=web", want: labelSelector{{key: "app", op: selectorEquals, value: "web"}}},
+		{selector: "app==web", want: labelSelector{{key: "app", op: selectorEquals, value: "web"}}},
+		{selector: "app!=web", want: labelSelector{{key: "app", op: selectorNotEquals, value: "web"}}},
+		{selector: "app", want: labelSelector{{key: "app", op: selectorExists}}},
+		{selector: "!app", want: labelSelector{{key: "app", op: selectorNotExists}}},
+		{selector: "app=", want: labelSelector{{key: "app", op: selectorEquals}}},
+		{selector: " app = web , !tier ", want: labelSelector{
+			{key: "app", op: selectorEquals, value: "web"},
+			{key: "tier", op: selectorNotExists},
+		}},
+		{selector: "team/app=web", want: labelSelector{{key: "team/app", op: selectorEquals, value: "web"}}},
+		{selector: "=web", err: true},
+		{selector: "app=web,", err: true},
+		{selector: "app=we b", err: true},
+		{selector: "app=-web", err: true},
+		{selector: "!", err: true},
+		{selector: "app===web", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := parseLabelSelector(tt.selector)
+			if tt.err {
+				if !errors.Is(err, ErrInvalidSelector) {
+					t.Errorf("got %v, want %v", err, ErrInvalidSelector)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(sel, tt.want) {
+				t.Errorf("got %+v, want %+v", sel, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := map[string]string{"app": "web", "tier": "frontend"}
+
+	tests := []struct {
+		selector string
+		match    bool
+	}{
+		{selector: "", match: true},
+		{selector: "app=web", match: true},
+		{selector: "app=db", match: false},
+		{selector: "app!=db", match: true},
+		{selector: "app!=web", match: false},
+		{selector: "owner!=ci", match: true},
+		{selector: "tier", match: true},
+		{selector: "owner", match: false},
+		{selector: "!owner", match: true},
+		{selector: "!tier", match: false},
+		{selector: "app=web,tier=frontend", match: true},
+		{selector: "app=web,tier=backend", match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := parseLabelSelector(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := sel.matches(labels); match != tt.match {
+				t.Errorf("got %v, want %v", match, tt.match)
+			}
+		})
+	}
+}
+
+func TestCheckLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		err    bool
+	}{
+		{name: "none"},
+		{name: "valid", labels: map[string]string{"app": "web", "team/owner": "ci-1", "empty": ""}},
+		{name: "empty key", labels: map[string]string{"": "web"}, err: true},
+		{name: "key with space", labels: map[string]string{"my app": "web"}, err: true},
+		{name: "value with slash", labels: map[string]string{"app": "a/b"}, err: true},
+		{name: "value ending in a dot", labels: map[string]string{"app": "web."}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLabels(tt.labels)
+			if tt.err != errors.Is(err, ErrInvalidLabels) {
+				t.Errorf("got %v, want error %v", err, tt.err)
+			}
+		})
+	}
+}
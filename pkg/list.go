@@ -0,0 +1,147 @@
+package goproc
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// ListProcesses returns the processes matching req in the order it asks for,
+// up to its page size.
+func (m *Manager) ListProcesses(ctx context.Context, req *proto.ListProcessesRequest) (*ProcessPage, error) {
+	infos, next, err := m.listProcesses(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ProcessPage{Processes: make([]*ProcessStatus, 0, len(infos)), NextPageToken: next}
+	for _, info := range infos {
+		page.Processes = append(page.Processes, processStatusOf(info))
+	}
+
+	return page, nil
+}
+
+// listProcesses returns a page of the processes matching req and the token of
+// the next page, if any.
+func (m *Manager) listProcesses(req *proto.ListProcessesRequest) ([]*proto.ProcessInfo, string, error) {
+	sel, err := parseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, "", err
+	}
+
+	order := req.OrderBy
+	if order == proto.ProcessOrder_PROCESS_ORDER_UNSPECIFIED {
+		order = proto.ProcessOrder_PROCESS_ORDER_START_TIME
+	}
+
+	var after *listCursor
+	if req.PageToken != "" {
+		after, err = decodePageToken(req.PageToken, order, req.Descending)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	var procs []*Process
+	m.processMap.Range(func(key, value any) bool {
+		proc := value.(*Process)
+		if listed(proc, req, sel) && (after == nil || compareCursors(cursorOf(proc, order, req.Descending), *after) > 0) {
+			procs = append(procs, proc)
+		}
+		return true
+	})
+
+	slices.SortFunc(procs, func(a, b *Process) int {
+		return compareCursors(cursorOf(a, order, req.Descending), cursorOf(b, order, req.Descending))
+	})
+
+	var next string
+	if req.PageSize > 0 && len(procs) > int(req.PageSize) {
+		procs = procs[:req.PageSize]
+		next = encodePageToken(cursorOf(procs[len(procs)-1], order, req.Descending))
+	}
+
+	infos := make([]*proto.ProcessInfo, 0, len(procs))
+	for _, proc := range procs {
+		infos = append(infos, processInfo(proc))
+	}
+
+	return infos, next, nil
+}
+
+// listed reports whether proc passes the filters of req.
+func listed(proc *Process, req *proto.ListProcessesRequest, sel labelSelector) bool {
+	if req.State != proto.ProcessState_PROCESS_STATE_UNSPECIFIED && processStates[proc.State()] != req.State {
+		return false
+	}
+
+	return strings.HasPrefix(proc.name, req.NamePrefix) && sel.matches(proc.labels)
+}
+
+// listCursor is the position of a process in a listing. Page tokens carry the
+// cursor of the last process of their page.
+type listCursor struct {
+	Order      proto.ProcessOrder `json:"o"`
+	Descending bool               `json:"d,omitempty"`
+	Name       string             `json:"n,omitempty"`
+	StartedAt  int64              `json:"t,omitempty"`
+	PID        int                `json:"p"`
+}
+
+func cursorOf(p *Process, order proto.ProcessOrder, descending bool) listCursor {
+	return listCursor{
+		Order:      order,
+		Descending: descending,
+		Name:       p.name,
+		StartedAt:  p.startedAt.UnixNano(),
+		PID:        p.pid,
+	}
+}
+
+// compareCursors orders cursors of the same listing, breaking ties by pid.
+func compareCursors(a, b listCursor) int {
+	var c int
+	switch a.Order {
+	case proto.ProcessOrder_PROCESS_ORDER_PID:
+		c = cmp.Compare(a.PID, b.PID)
+	case proto.ProcessOrder_PROCESS_ORDER_NAME:
+		c = cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.StartedAt, b.StartedAt), cmp.Compare(a.PID, b.PID))
+	default:
+		c = cmp.Or(cmp.Compare(a.StartedAt, b.StartedAt), cmp.Compare(a.PID, b.PID))
+	}
+
+	if a.Descending {
+		return -c
+	}
+	return c
+}
+
+func encodePageToken(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string, order proto.ProcessOrder, descending bool) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if c.Order != order || c.Descending != descending {
+		return nil, fmt.Errorf("%w: the token is for a different order", ErrInvalidPageToken)
+	}
+
+	return &c, nil
+}
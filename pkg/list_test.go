@@ -0,0 +1,148 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestCompareCursors(t *testing.T) {
+	cursor := func(order proto.ProcessOrder, descending bool, name string, startedAt int64, pid int) listCursor {
+		return listCursor{Order: order, Descending: descending, Name: name, StartedAt: startedAt, PID: pid}
+	}
+	const (
+		byStart = proto.ProcessOrder_PROCESS_ORDER_START_TIME
+		byPID   = proto.ProcessOrder_PROCESS_ORDER_PID
+		byName  = proto.ProcessOrder_PROCESS_ORDER_NAME
+	)
+
+	tests := []struct {
+		name string
+		a, b listCursor
+		want int
+	}{
+		{name: "start time", a: cursor(byStart, false, "b", 1, 20), b: cursor(byStart, false, "a", 2, 10), want: -1},
+		{name: "start time tie broken by pid", a: cursor(byStart, false, "a", 1, 20), b: cursor(byStart, false, "a", 1, 10), want: 1},
+		{name: "start time descending", a: cursor(byStart, true, "a", 1, 10), b: cursor(byStart, true, "a", 2, 10), want: 1},
+		{name: "pid", a: cursor(byPID, false, "a", 2, 10), b: cursor(byPID, false, "b", 1, 20), want: -1},
+		{name: "pid descending", a: cursor(byPID, true, "a", 2, 10), b: cursor(byPID, true, "b", 1, 20), want: 1},
+		{name: "name", a: cursor(byName, false, "b", 1, 10), b: cursor(byName, false, "a", 2, 20), want: 1},
+		{name: "name tie broken by start time", a: cursor(byName, false, "a", 1, 20), b: cursor(byName, false, "a", 2, 10), want: -1},
+		{name: "name and start time tie broken by pid", a: cursor(byName, false, "a", 1, 20), b: cursor(byName, false, "a", 1, 10), want: 1},
+		{name: "same process", a: cursor(byName, true, "a", 1, 10), b: cursor(byName, true, "a", 1, 10), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareCursors(tt.a, tt.b); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePageToken(t *testing.T) {
+	c := listCursor{Order: proto.ProcessOrder_PROCESS_ORDER_NAME, Name: "web", StartedAt: 42, PID: 7}
+	token := encodePageToken(c)
+
+	tests := []struct {
+		name       string
+		token      string
+		order      proto.ProcessOrder
+		descending bool
+		err        bool
+	}{
+		{name: "same listing", token: token, order: proto.ProcessOrder_PROCESS_ORDER_NAME},
+		{name: "other order", token: token, order: proto.ProcessOrder_PROCESS_ORDER_PID, err: true},
+		{name: "other direction", token: token, order: proto.ProcessOrder_PROCESS_ORDER_NAME, descending: true, err: true},
+		{name: "not base64", token: "not a token!", order: proto.ProcessOrder_PROCESS_ORDER_NAME, err: true},
+		{name: "not json", token: "bm90IGpzb24", order: proto.ProcessOrder_PROCESS_ORDER_NAME, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageToken(tt.token, tt.order, tt.descending)
+			if tt.err {
+				if !errors.Is(err, ErrInvalidPageToken) {
+					t.Errorf("got %v, want %v", err, ErrInvalidPageToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != c {
+				t.Errorf("got %+v, want %+v", *got, c)
+			}
+		})
+	}
+}
+
+func TestListProcessesPages(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+
+	// Names run against start order so the orders disagree.
+	for i := 0; i < 5; i++ {
+		res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{
+			Args:   []string{"true"},
+			Name:   fmt.Sprintf("job-%d", 4-i),
+			Labels: map[string]string{"parity": fmt.Sprint(i % 2)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Wait(context.Background(), res.PID)
+	}
+
+	tests := []struct {
+		name string
+		req  *proto.ListProcessesRequest
+		want []string
+	}{
+		{name: "start time", req: &proto.ListProcessesRequest{}, want: []string{"job-4", "job-3", "job-2", "job-1", "job-0"}},
+		{name: "start time descending", req: &proto.ListProcessesRequest{Descending: true}, want: []string{"job-0", "job-1", "job-2", "job-3", "job-4"}},
+		{name: "name", req: &proto.ListProcessesRequest{OrderBy: proto.ProcessOrder_PROCESS_ORDER_NAME}, want: []string{"job-0", "job-1", "job-2", "job-3", "job-4"}},
+		{name: "name descending", req: &proto.ListProcessesRequest{OrderBy: proto.ProcessOrder_PROCESS_ORDER_NAME, Descending: true}, want: []string{"job-4", "job-3", "job-2", "job-1", "job-0"}},
+		{name: "selector", req: &proto.ListProcessesRequest{LabelSelector: "parity=0"}, want: []string{"job-4", "job-2", "job-0"}},
+		{name: "name prefix", req: &proto.ListProcessesRequest{NamePrefix: "job-3"}, want: []string{"job-3"}},
+	}
+
+	for _, tt := range tests {
+		for _, pageSize := range []int32{0, 1, 2, 5, 6} {
+			t.Run(fmt.Sprintf("%s/page size %d", tt.name, pageSize), func(t *testing.T) {
+				req := tt.req
+				req.PageSize = pageSize
+				req.PageToken = ""
+
+				var names []string
+				for pages := 1; ; pages++ {
+					infos, next, err := m.listProcesses(req)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if pageSize > 0 && len(infos) > int(pageSize) {
+						t.Fatalf("got %d processes on a page of %d", len(infos), pageSize)
+					}
+					for _, info := range infos {
+						names = append(names, info.Name)
+					}
+					if next == "" {
+						break
+					}
+					if pages > len(tt.want) {
+						t.Fatalf("listing did not end after %d pages", pages)
+					}
+					req.PageToken = next
+				}
+
+				if !slices.Equal(names, tt.want) {
+					t.Errorf("got %v, want %v", names, tt.want)
+				}
+			})
+		}
+	}
+}
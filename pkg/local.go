@@ -0,0 +1,157 @@
+package goproc
+
+import (
+	"context"
+	"io"
+	"syscall"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// Client is implemented by GoProcClient, which talks to a server, and by
+// LocalClient, which calls a Manager in the same process.
+type Client interface {
+	Exec(ctx context.Context, args []string, opts ...ExecOption) (*ExecResult, error)
+	ExecUpload(ctx context.Context, args []string, r io.Reader, opts ...ExecOption) (*ExecResult, error)
+	Wait(ctx context.Context, pid int) (*WaitResult, error)
+	Kill(ctx context.Context, pid int) error
+	Signal(ctx context.Context, pid int, sig syscall.Signal) error
+	KillMatching(ctx context.Context, selector string) ([]*SignalResult, error)
+	SignalMatching(ctx context.Context, selector string, sig syscall.Signal) ([]*SignalResult, error)
+	WaitAll(ctx context.Context, selector string) ([]*WaitResult, error)
+	Status(ctx context.Context, pid int) (*ProcessStatus, error)
+	Stdout(ctx context.Context, pid int) (string, error)
+	Stderr(ctx context.Context, pid int) (string, error)
+	ListProcesses(ctx context.Context, opts ...ListOption) ([]*ProcessStatus, error)
+	ListProcessesPage(ctx context.Context, pageSize int, pageToken string, opts ...ListOption) (*ProcessPage, error)
+	StreamOutput(ctx context.Context, pid int, follow bool, stdout, stderr io.Writer) (*WaitResult, error)
+	Attach(ctx context.Context, pid int, streams AttachStreams) (*WaitResult, error)
+	WatchEvents(ctx context.Context, req *proto.WatchEventsRequest, fn func(*proto.ProcessEvent) error) error
+	Cleanup() error
+}
+
+var (
+	_ Client = (*GoProcClient)(nil)
+	_ Client = (*LocalClient)(nil)
+)
+
+// LocalClient is a Client backed by a Manager in the same process. Errors are
+// the package errors themselves rather than RemoteErrors wrapping them.
+type LocalClient struct {
+	manager *Manager
+}
+
+func NewLocalClient(manager *Manager) *LocalClient {
+	return &LocalClient{manager: manager}
+}
+
+func (c *LocalClient) Exec(ctx context.Context, args []string, opts ...ExecOption) (*ExecResult, error) {
+	return c.manager.Exec(ctx, execRequest(args, opts))
+}
+
+func (c *LocalClient) ExecUpload(ctx context.Context, args []string, r io.Reader, opts ...ExecOption) (*ExecResult, error) {
+	return c.manager.ExecUpload(ctx, execRequest(args, opts), r)
+}
+
+func (c *LocalClient) Wait(ctx context.Context, pid int) (*WaitResult, error) {
+	return c.manager.Wait(ctx, pid)
+}
+
+func (c *LocalClient) Kill(ctx context.Context, pid int) error {
+	return c.manager.Kill(ctx, pid)
+}
+
+func (c *LocalClient) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	return c.manager.Signal(ctx, pid, sig)
+}
+
+func (c *LocalClient) KillMatching(ctx context.Context, selector string) ([]*SignalResult, error) {
+	return c.manager.KillMatching(ctx, selector)
+}
+
+func (c *LocalClient) SignalMatching(ctx context.Context, selector string, sig syscall.Signal) ([]*SignalResult, error) {
+	return c.manager.SignalMatching(ctx, selector, sig)
+}
+
+func (c *LocalClient) WaitAll(ctx context.Context, selector string) ([]*WaitResult, error) {
+	return c.manager.WaitAll(ctx, selector)
+}
+
+func (c *LocalClient) Status(ctx context.Context, pid int) (*ProcessStatus, error) {
+	return c.manager.Status(ctx, pid)
+}
+
+func (c *LocalClient) Stdout(ctx context.Context, pid int) (string, error) {
+	return c.manager.Stdout(ctx, pid)
+}
+
+func (c *LocalClient) Stderr(ctx context.Context, pid int) (string, error) {
+	return c.manager.Stderr(ctx, pid)
+}
+
+func (c *LocalClient) ListProcesses(ctx context.Context, opts ...ListOption) ([]*ProcessStatus, error) {
+	page, err := c.manager.ListProcesses(ctx, listRequest(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Processes, nil
+}
+
+func (c *LocalClient) ListProcessesPage(ctx context.Context, pageSize int, pageToken string, opts ...ListOption) (*ProcessPage, error) {
+	req := listRequest(opts)
+	req.PageSize = int32(pageSize)
+	req.PageToken = pageToken
+
+	return c.manager.ListProcesses(ctx, req)
+}
+
+func (c *LocalClient) StreamOutput(ctx context.Context, pid int, follow bool, stdout, stderr io.Writer) (*WaitResult, error) {
+	w := &outputWriter{pid: pid, stdout: stdout, stderr: stderr}
+	if err := c.manager.StreamOutput(ctx, pid, follow, w.write); err != nil {
+		return nil, err
+	}
+
+	return w.exit, nil
+}
+
+func (c *LocalClient) Attach(ctx context.Context, pid int, streams AttachStreams) (*WaitResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	input := make(chan *proto.AttachRequest)
+	sendAttachInput(ctx, streams, func(req *proto.AttachRequest) error {
+		select {
+		case input <- req:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	recv := func(ctx context.Context) (*proto.AttachRequest, error) {
+		select {
+		case req := <-input:
+			return req, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	w := &outputWriter{pid: pid, stdout: streams.Stdout, stderr: streams.Stderr}
+	if err := c.manager.Attach(ctx, pid, recv, w.write); err != nil {
+		return nil, err
+	}
+
+	return w.exit, nil
+}
+
+func (c *LocalClient) WatchEvents(ctx context.Context, req *proto.WatchEventsRequest, fn func(*proto.ProcessEvent) error) error {
+	return c.manager.WatchEvents(ctx, req, fn)
+}
+
+// Cleanup is a no-op: the processes belong to the Manager, which outlives its
+// clients. Call Manager.Shutdown to stop them.
+func (c *LocalClient) Cleanup() error {
+	return nil
+}
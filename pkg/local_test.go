@@ -0,0 +1,101 @@
+package goproc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestLocalClientMatchesRemote runs the same calls through a LocalClient and a
+// GoProcClient, which should only differ in how their errors are wrapped.
+func TestLocalClientMatchesRemote(t *testing.T) {
+	clients := []struct {
+		name   string
+		client func(t *testing.T) Client
+		remote bool
+	}{
+		{name: "local", client: func(t *testing.T) Client {
+			return NewLocalClient(newTestManager(t, GoProcConfig{}))
+		}},
+		{name: "remote", remote: true, client: func(t *testing.T) Client {
+			return startTestServer(t, newTestServer(t, GoProcConfig{}))
+		}},
+	}
+
+	for _, cc := range clients {
+		t.Run(cc.name, func(t *testing.T) {
+			c := cc.client(t)
+			ctx := context.Background()
+
+			res, err := c.Exec(ctx, []string{"sh", "-c", "echo out; echo err >&2; exit 3"}, WithLabels(map[string]string{"job": "local"}), WithWait())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !res.Exited || res.ExitCode != 3 {
+				t.Errorf("got %+v, want exit code 3", res)
+			}
+
+			if out, err := c.Stdout(ctx, res.PID); err != nil || out != "out\n" {
+				t.Errorf("got stdout %q, %v", out, err)
+			}
+			if out, err := c.Stderr(ctx, res.PID); err != nil || out != "err\n" {
+				t.Errorf("got stderr %q, %v", out, err)
+			}
+
+			procs, err := c.ListProcesses(ctx, WithLabelSelector("job=local"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(procs) != 1 || procs[0].PID != res.PID || procs[0].ExitCode != 3 {
+				t.Errorf("got %+v, want process %d", procs, res.PID)
+			}
+
+			cat, err := c.Exec(ctx, []string{"cat"}, WithStdin())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var stdout bytes.Buffer
+			wres, err := c.Attach(ctx, cat.PID, AttachStreams{Stdin: strings.NewReader("hello"), Stdout: &stdout})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if wres.ExitCode != 0 || stdout.String() != "hello" {
+				t.Errorf("got %+v with output %q, want cat to echo its input", wres, stdout.String())
+			}
+
+			_, err = c.Status(ctx, 999999)
+			if !errors.Is(err, ErrProcessNotFound) {
+				t.Fatalf("got %v, want %v", err, ErrProcessNotFound)
+			}
+			var remoteErr *RemoteError
+			if errors.As(err, &remoteErr) != cc.remote {
+				t.Errorf("got %T, want a RemoteError: %v", err, cc.remote)
+			}
+		})
+	}
+}
+
+func TestLocalClientCleanupLeavesProcesses(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	c := NewLocalClient(m)
+	ctx := context.Background()
+
+	res, err := c.Exec(ctx, []string{"sleep", "60"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := NewLocalClient(m).Status(ctx, res.PID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != ProcessRunning {
+		t.Errorf("got state %s, want the process still running", status.State)
+	}
+}
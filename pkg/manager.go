@@ -0,0 +1,673 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Manager owns the process table and implements every process operation.
+// GoProcServer serves a Manager over gRPC, and it can be used in-process on
+// its own, directly or through a LocalClient. Errors are the package errors,
+// such as ErrProcessNotFound.
+type Manager struct {
+	cfg        GoProcConfig
+	processMap sync.Map
+	policy     *policy
+	metrics    *serverMetrics
+	events     *eventBus
+	registry   *registry
+	reaper     *reaper
+	forwarded  []os.Signal
+	execs      *execDeduper
+	redactor   *envRedactor
+
+	// startMu is held for reading while a process starts and for writing by
+	// bulk operations, so that they see every process that has started and
+	// none starts meanwhile.
+	startMu sync.RWMutex
+
+	// stopped is cancelled by Shutdown, ending the calls waiting on
+	// processes or events so that they don't hold the server up.
+	stopped context.Context
+	stop    context.CancelFunc
+}
+
+// NewManager creates a Manager from the policy, redaction, event, idempotency,
+// registry, init and shutdown settings of cfg. With the registry enabled,
+// processes left by an earlier instance are restored.
+func NewManager(cfg GoProcConfig) (*Manager, error) {
+	policy, err := newPolicy(cfg.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateShutdownConfig(cfg.Shutdown); err != nil {
+		return nil, err
+	}
+
+	redactor, err := newEnvRedactor(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{cfg: cfg, policy: policy, redactor: redactor, events: newEventBus(cfg.EventHistorySize)}
+	m.stopped, m.stop = context.WithCancel(context.Background())
+	m.metrics = newServerMetrics(m)
+	m.execs = newExecDeduper(time.Duration(cfg.IdempotencyWindowS) * time.Second)
+
+	if cfg.Init.Enabled {
+		m.forwarded, err = parseSignals(cfg.Init.ForwardSignals)
+		if err != nil {
+			return nil, err
+		}
+
+		m.reaper, err = startReaper()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Registry.Enabled {
+		m.registry, err = newRegistry(cfg.Registry)
+		if err != nil {
+			return nil, err
+		}
+
+		processes, err := m.registry.restore(m.processExited)
+		if err != nil {
+			return nil, err
+		}
+
+		// Records saved before the current rules may hold values they
+		// redact.
+		for _, proc := range processes {
+			proc.reportEnv = m.redactor.redact(proc.reportedEnv(), nil)
+			m.processMap.Store(proc.pid, proc)
+		}
+	}
+
+	return m, nil
+}
+
+// Exec starts a process. Requests with an idempotency key are deduplicated
+// per caller, as for the Exec RPC. If the call fails once the process has
+// started, such as while waiting for it, the result carrying its pid is
+// returned with the error.
+func (m *Manager) Exec(ctx context.Context, req *proto.ExecProcessRequest) (*ExecResult, error) {
+	return m.execs.do(ctx, req, func() (*ExecResult, error) {
+		return m.countExec(m.exec(ctx, req, nil))
+	})
+}
+
+// ExecUpload starts the process described by req with stdin read from
+// upload, which is closed at end-of-file. If req waits, the result is
+// returned once the process has exited; otherwise once all of upload has been
+// written. A request repeating an idempotency key gets the earlier result and
+// its upload is not read.
+func (m *Manager) ExecUpload(ctx context.Context, req *proto.ExecProcessRequest, upload io.Reader) (*ExecResult, error) {
+	return m.execs.do(ctx, req, func() (*ExecResult, error) {
+		return m.countExec(m.exec(ctx, req, upload))
+	})
+}
+
+func (m *Manager) countExec(res *ExecResult, err error) (*ExecResult, error) {
+	if res == nil {
+		m.metrics.execsFailed.Inc()
+		return nil, err
+	}
+
+	m.metrics.execsStarted.Inc()
+	return res, err
+}
+
+// exec starts the process described by req, with stdin copied from upload
+// when it is not nil. Once the process has started, the result is returned
+// even if the call fails, so that the caller can still reach the process; a
+// process whose upload failed has been killed.
+func (m *Manager) exec(ctx context.Context, req *proto.ExecProcessRequest, upload io.Reader) (*ExecResult, error) {
+	commands, err := execCommands(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStdin(req, upload != nil); err != nil {
+		return nil, err
+	}
+
+	if err := checkEnv(req); err != nil {
+		return nil, err
+	}
+
+	if err := checkLabels(req.Labels); err != nil {
+		return nil, err
+	}
+
+	if err := checkOutputTargets(req); err != nil {
+		return nil, err
+	}
+
+	// Every stage of a pipeline has to pass the policy on its own.
+	for _, args := range commands {
+		err := m.policy.check(newPolicyRequest(args, req.Cwd, requestedEnv(req), requestedPaths(req)))
+		if err != nil {
+			log.Warn().Err(err).Strs("args", args).Str("caller", callerFromContext(ctx)).Msg("Exec blocked by policy")
+			return nil, err
+		}
+	}
+
+	env, reportEnv, err := composeEnv(req)
+	if err != nil {
+		return nil, err
+	}
+
+	proc, err := NewProcess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Opened before taking startMu, which a blocking open would otherwise
+	// hold up bulk operations and every other start behind.
+	openCtx, cancelOpen := m.stopContext(ctx)
+	files, err := openExecFiles(openCtx, req)
+	cancelOpen()
+	if err != nil {
+		return nil, err
+	}
+	defer files.close()
+
+	m.startMu.RLock()
+	var started sync.Once
+	release := func() { started.Do(m.startMu.RUnlock) }
+	defer release()
+
+	proc.onStart = func(p *Process) {
+		m.processStarted(p)
+		release()
+	}
+	proc.onExit = m.processExited
+	proc.openStdin = req.Stdin || upload != nil
+	proc.stdinData = req.StdinData
+	proc.files = files
+	proc.reportEnv = m.redactor.redact(reportEnv, req.SensitiveEnv)
+	proc.name = req.Name
+	proc.labels = req.Labels
+	proc.stdoutTarget = req.Stdout
+	proc.stderrTarget = req.Stderr
+	proc.reaper = m.reaper
+
+	if m.registry != nil {
+		proc.logDir = m.registry.logDir
+	}
+
+	if req.Tty {
+		size := terminalSize(req.TerminalSize)
+		proc.terminal = &size
+	}
+
+	var pid int
+	if req.Pipeline != nil {
+		pid, err = proc.execPipeline(newPipeline(req.Pipeline, req.Cwd, env), false)
+	} else {
+		pid, err = proc.Exec(req.Args, req.Cwd, env, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ExecResult{PID: pid, ExitCode: -1}
+
+	// An upload has to be written before the process can be waited for.
+	if upload != nil {
+		if err := uploadStdin(ctx, proc, upload); err != nil {
+			return res, err
+		}
+	}
+
+	wait := req.GetWait()
+	if wait {
+		if err := m.waitFor(ctx, proc); err != nil {
+			return res, err
+		}
+	}
+
+	if wait {
+		res.Exited = true
+		res.ExitCode = proc.ExitCode()
+		res.StageExitCodes = proc.StageExitCodes()
+	}
+
+	return res, nil
+}
+
+// waitFor blocks until proc exits, ctx is done or the manager shuts down.
+func (m *Manager) waitFor(ctx context.Context, proc *Process) error {
+	select {
+	case <-proc.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-m.stopped.Done():
+		return ErrServerShuttingDown
+	}
+}
+
+// stopContext returns ctx, also cancelled with ErrServerShuttingDown once the
+// manager shuts down. context.Cause reports which of them ended it.
+func (m *Manager) stopContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(m.stopped, func() { cancel(ErrServerShuttingDown) })
+
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// Wait blocks until the process exits, ctx is done or the manager shuts down.
+func (m *Manager) Wait(ctx context.Context, pid int) (*WaitResult, error) {
+	proc, err := m.process(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := m.stopContext(ctx)
+	defer cancel()
+
+	select {
+	case <-proc.done:
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+
+	return &WaitResult{PID: pid, ExitCode: proc.ExitCode(), StageExitCodes: proc.StageExitCodes()}, nil
+}
+
+func (m *Manager) Kill(ctx context.Context, pid int) error {
+	proc, err := m.process(pid)
+	if err != nil {
+		return err
+	}
+
+	if err := proc.Kill(); err != nil {
+		return err
+	}
+
+	m.events.publish(proto.ProcessEventType_PROCESS_EVENT_SIGNALED, processInfo(proc), int32(syscall.SIGKILL))
+	return nil
+}
+
+func (m *Manager) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	proc, err := m.process(pid)
+	if err != nil {
+		return err
+	}
+
+	if err := proc.Signal(sig); err != nil {
+		return err
+	}
+
+	m.events.publish(proto.ProcessEventType_PROCESS_EVENT_SIGNALED, processInfo(proc), int32(sig))
+	return nil
+}
+
+func (m *Manager) Status(ctx context.Context, pid int) (*ProcessStatus, error) {
+	info, err := m.processInfo(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return processStatusOf(info), nil
+}
+
+func (m *Manager) Stdout(ctx context.Context, pid int) (string, error) {
+	proc, err := m.process(pid)
+	if err != nil {
+		return "", err
+	}
+
+	return proc.Stdout(), nil
+}
+
+func (m *Manager) Stderr(ctx context.Context, pid int) (string, error) {
+	proc, err := m.process(pid)
+	if err != nil {
+		return "", err
+	}
+
+	return proc.Stderr(), nil
+}
+
+// StreamOutput passes the output buffered so far to fn and, with follow,
+// every later write until the process exits. The last chunk carries the exit
+// code.
+func (m *Manager) StreamOutput(ctx context.Context, pid int, follow bool, fn func(*proto.OutputChunk) error) error {
+	proc, err := m.process(pid)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := m.stopContext(ctx)
+	defer cancel()
+
+	return streamOutput(ctx, proc, follow, fn)
+}
+
+// Attach streams output to fn like a followed StreamOutput while applying the
+// stdin data and terminal resizes returned by recv. recv ending with an error
+// only ends input. recv must return once the ctx it is passed is done: Attach
+// waits for it before returning, so input is never read or applied once
+// Attach has returned.
+func (m *Manager) Attach(ctx context.Context, pid int, recv func(context.Context) (*proto.AttachRequest, error), fn func(*proto.OutputChunk) error) error {
+	proc, err := m.process(pid)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := m.stopContext(ctx)
+	defer cancel()
+
+	inputErr := make(chan error, 1)
+	inputDone := make(chan struct{})
+	go func() {
+		defer close(inputDone)
+		for {
+			req, err := recv(ctx)
+			if err != nil || ctx.Err() != nil {
+				return
+			}
+
+			if err := applyAttachInput(proc, req); err != nil {
+				inputErr <- err
+				cancel()
+				return
+			}
+		}
+	}()
+
+	err = streamOutput(ctx, proc, true, fn)
+	cancel()
+	<-inputDone
+
+	select {
+	case err := <-inputErr:
+		return err
+	default:
+		return err
+	}
+}
+
+// WatchEvents passes process lifecycle events to fn until ctx is done or fn
+// returns an error. Events missed since req.ResumeToken are passed first.
+func (m *Manager) WatchEvents(ctx context.Context, req *proto.WatchEventsRequest, fn func(*proto.ProcessEvent) error) error {
+	sub, backlog, err := m.events.subscribe(req.Pids, req.Types, req.ResumeToken)
+	if err != nil {
+		return err
+	}
+	defer m.events.unsubscribe(sub)
+
+	ctx, cancel := m.stopContext(ctx)
+	defer cancel()
+
+	for _, ev := range backlog {
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case ev, ok := <-sub.ch:
+			if !ok {
+				return ErrSubscriberTooSlow
+			}
+
+			if err := fn(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Shutdown applies the shutdown policy to the processes still running, then
+// ends the calls still waiting on processes or events with
+// ErrServerShuttingDown.
+func (m *Manager) Shutdown() {
+	m.stopProcesses()
+	m.stop()
+
+	if m.registry != nil {
+		m.registry.close()
+	}
+}
+
+func (m *Manager) processStarted(proc *Process) {
+	m.processMap.Store(proc.pid, proc)
+	if m.registry != nil {
+		m.registry.update(proc)
+	}
+	m.events.publish(proto.ProcessEventType_PROCESS_EVENT_STARTED, processInfo(proc), 0)
+}
+
+func (m *Manager) processExited(proc *Process) {
+	m.metrics.processExited(proc)
+
+	var pruned []processRecord
+	if m.registry != nil {
+		pruned = m.registry.update(proc)
+	}
+	m.events.publish(proto.ProcessEventType_PROCESS_EVENT_EXITED, processInfo(proc), 0)
+
+	for _, rec := range pruned {
+		m.removeProcess(rec)
+	}
+}
+
+// removeProcess drops a pruned record's process from the process table,
+// unless its pid has been reused since.
+func (m *Manager) removeProcess(rec processRecord) {
+	value, ok := m.processMap.Load(rec.PID)
+	if !ok || value.(*Process).id != rec.ID {
+		return
+	}
+
+	if m.processMap.CompareAndDelete(rec.PID, value) {
+		m.events.publish(proto.ProcessEventType_PROCESS_EVENT_REMOVED, processInfo(value.(*Process)), 0)
+	}
+}
+
+func (m *Manager) process(pid int) (*Process, error) {
+	procIface, ok := m.processMap.Load(pid)
+	if !ok {
+		return nil, ErrProcessNotFound
+	}
+
+	proc, ok := procIface.(*Process)
+	if !ok {
+		return nil, ErrProcessNotFound
+	}
+
+	return proc, nil
+}
+
+func (m *Manager) processInfo(pid int) (*proto.ProcessInfo, error) {
+	proc, err := m.process(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return processInfo(proc), nil
+}
+
+// runningProcesses returns the running processes, most recently started first.
+func (m *Manager) runningProcesses() []*Process {
+	procs := make([]*Process, 0)
+	m.processMap.Range(func(key, value any) bool {
+		if proc := value.(*Process); proc.Running() {
+			procs = append(procs, proc)
+		}
+		return true
+	})
+
+	sort.Slice(procs, func(i, j int) bool {
+		return procs[i].startedAt.After(procs[j].startedAt)
+	})
+
+	return procs
+}
+
+func processInfo(proc *Process) *proto.ProcessInfo {
+	return &proto.ProcessInfo{
+		Pid:               int32(proc.pid),
+		Cmd:               proc.command(),
+		Cwd:               proc.cmd.Dir,
+		Env:               proc.reportedEnv(),
+		Running:           proc.Running(),
+		ExitCode:          int32(proc.ExitCode()),
+		Stdin:             proc.HasStdin(),
+		Tty:               proc.HasTerminal(),
+		State:             processStates[proc.State()],
+		Stages:            proc.stageInfos(),
+		Name:              proc.name,
+		Labels:            proc.labels,
+		StartedAtUnixNano: proc.startedAt.UnixNano(),
+	}
+}
+
+var processStates = map[ProcessState]proto.ProcessState{
+	ProcessRunning: proto.ProcessState_PROCESS_STATE_RUNNING,
+	ProcessExited:  proto.ProcessState_PROCESS_STATE_EXITED,
+	ProcessLost:    proto.ProcessState_PROCESS_STATE_LOST,
+}
+
+func applyAttachInput(proc *Process, req *proto.AttachRequest) error {
+	err := attachInput(proc, req)
+	if errors.Is(err, ErrProcessExited) {
+		// The output side reports the exit.
+		return nil
+	}
+
+	return err
+}
+
+func attachInput(p *Process, req *proto.AttachRequest) error {
+	if req.Resize != nil {
+		if err := p.Resize(terminalSize(req.Resize)); err != nil {
+			return err
+		}
+	}
+
+	if len(req.Stdin) > 0 {
+		if _, err := p.WriteStdin(req.Stdin); err != nil {
+			return err
+		}
+	}
+
+	if req.CloseStdin {
+		return p.CloseStdin()
+	}
+
+	return nil
+}
+
+// terminalSize converts a requested terminal size, using the default for
+// unset dimensions.
+func terminalSize(size *proto.TerminalSize) TerminalSize {
+	ts := TerminalSize{Rows: defaultTerminalRows, Cols: defaultTerminalCols}
+	if size.GetRows() > 0 {
+		ts.Rows = uint16(size.GetRows())
+	}
+	if size.GetCols() > 0 {
+		ts.Cols = uint16(size.GetCols())
+	}
+
+	return ts
+}
+
+// streamOutput sends the output buffered so far and, with follow, every later
+// write until the process exits or ctx is done. A follower slower than the
+// process catches up from the output buffers.
+func streamOutput(ctx context.Context, proc *Process, follow bool, send func(*proto.OutputChunk) error) error {
+	stdout, stdoutSub := proc.stdoutBuf.Subscribe()
+	defer stdoutSub.Cancel()
+	stderr, stderrSub := proc.stderrBuf.Subscribe()
+	defer stderrSub.Cancel()
+
+	if err := sendOutput(send, proto.OutputStream_OUTPUT_STREAM_STDOUT, stdout); err != nil {
+		return err
+	}
+	if err := sendOutput(send, proto.OutputStream_OUTPUT_STREAM_STDERR, stderr); err != nil {
+		return err
+	}
+
+	if !follow {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-stdoutSub.Ready():
+			if err := drainOutput(send, proto.OutputStream_OUTPUT_STREAM_STDOUT, stdoutSub); err != nil {
+				return err
+			}
+		case <-stderrSub.Ready():
+			if err := drainOutput(send, proto.OutputStream_OUTPUT_STREAM_STDERR, stderrSub); err != nil {
+				return err
+			}
+		case <-proc.done:
+			// All output has been written once the process is waited on, so
+			// whatever is left unread is the tail of the stream.
+			if err := drainOutput(send, proto.OutputStream_OUTPUT_STREAM_STDOUT, stdoutSub); err != nil {
+				return err
+			}
+			if err := drainOutput(send, proto.OutputStream_OUTPUT_STREAM_STDERR, stderrSub); err != nil {
+				return err
+			}
+
+			exitCode := int32(proc.ExitCode())
+			return send(&proto.OutputChunk{ExitCode: &exitCode})
+		}
+	}
+}
+
+func sendOutput(send func(*proto.OutputChunk) error, which proto.OutputStream, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return send(&proto.OutputChunk{Stream: which, Data: data})
+}
+
+// drainOutput sends what sub has not read yet, reporting any bytes it missed.
+func drainOutput(send func(*proto.OutputChunk) error, which proto.OutputStream, sub *BufferSubscription) error {
+	for {
+		data, skipped := sub.Next()
+		if len(data) == 0 && skipped == 0 {
+			return nil
+		}
+
+		if skipped > 0 {
+			err := send(&proto.OutputChunk{Stream: which, Data: data, SkippedBytes: uint64(skipped)})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := sendOutput(send, which, data); err != nil {
+			return err
+		}
+	}
+}
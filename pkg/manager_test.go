@@ -0,0 +1,299 @@
+package goproc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestExecOpeningFIFODoesNotBlockOthers(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	fifo := filepath.Join(t.TempDir(), "stdin")
+	if err := syscall.Mkfifo(fifo, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Blocks opening the FIFO until a writer opens it.
+	blocked := make(chan error, 1)
+	go func() {
+		_, err := m.Exec(context.Background(), &proto.ExecProcessRequest{Args: []string{"cat"}, StdinFile: fifo})
+		blocked <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{name: "KillMatching", call: func(ctx context.Context) error {
+			_, err := m.KillMatching(ctx, "job=none")
+			return err
+		}},
+		{name: "Exec", call: func(ctx context.Context) error {
+			_, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"true"}})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan error, 1)
+			go func() { done <- tt.call(context.Background()) }()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatal(err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("blocked behind the FIFO open")
+			}
+		})
+	}
+
+	w, err := os.OpenFile(fifo, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	if err := <-blocked; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamOutputSlowFollower(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	// One write per line, far more than a follower could be sent at once.
+	script := "for i in $(seq 1 20000); do echo $i; done"
+	res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{Args: []string{"sh", "-c", script}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	var exitCode *int32
+	err = m.StreamOutput(context.Background(), res.PID, true, func(chunk *proto.OutputChunk) error {
+		// Fall far behind the process on the first chunk.
+		if out.Len() == 0 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		if chunk.SkippedBytes > 0 {
+			t.Errorf("skipped %d bytes", chunk.SkippedBytes)
+		}
+		out.Write(chunk.Data)
+		exitCode = chunk.ExitCode
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := exec.Command("seq", "1", "20000").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("got %d bytes of output, want %d", out.Len(), len(want))
+	}
+	if exitCode == nil || *exitCode != 0 {
+		t.Errorf("got exit code %v, want 0", exitCode)
+	}
+}
+
+func TestShutdownEndsWaitingCalls(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{Shutdown: ShutdownConfig{Policy: ShutdownLeave}})
+	res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Labels: map[string]string{"job": "sleep"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, proc := range m.runningProcesses() {
+			proc.Kill()
+		}
+	}()
+
+	fifo := filepath.Join(t.TempDir(), "stdin")
+	if err := syscall.Mkfifo(fifo, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	discard := func(*proto.OutputChunk) error { return nil }
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "Wait", call: func() error {
+			_, err := m.Wait(ctx, res.PID)
+			return err
+		}},
+		{name: "WaitAll", call: func() error {
+			_, err := m.WaitAll(ctx, "job=sleep")
+			return err
+		}},
+		{name: "StreamOutput", call: func() error {
+			return m.StreamOutput(ctx, res.PID, true, discard)
+		}},
+		{name: "Attach", call: func() error {
+			recv := func(ctx context.Context) (*proto.AttachRequest, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return m.Attach(ctx, res.PID, recv, discard)
+		}},
+		{name: "WatchEvents", call: func() error {
+			return m.WatchEvents(ctx, &proto.WatchEventsRequest{}, func(*proto.ProcessEvent) error { return nil })
+		}},
+		{name: "Exec", call: func() error {
+			wait := true
+			_, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Wait: &wait})
+			return err
+		}},
+		{name: "Exec opening a FIFO", call: func() error {
+			_, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"cat"}, StdinFile: fifo})
+			return err
+		}},
+	}
+
+	errs := make([]chan error, len(tests))
+	for i, tt := range tests {
+		errs[i] = make(chan error, 1)
+		go func() { errs[i] <- tt.call() }()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	m.Shutdown()
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			select {
+			case err := <-errs[i]:
+				if !errors.Is(err, ErrServerShuttingDown) {
+					t.Errorf("got %v, want %v", err, ErrServerShuttingDown)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("still running after shutdown")
+			}
+		})
+	}
+}
+
+func TestExecEndsWithContext(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	fifo := filepath.Join(t.TempDir(), "stdin")
+	if err := syscall.Mkfifo(fifo, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	wait := true
+	tests := []struct {
+		name    string
+		req     *proto.ExecProcessRequest
+		started bool
+	}{
+		{name: "opening a FIFO", req: &proto.ExecProcessRequest{Args: []string{"cat"}, StdinFile: fifo}},
+		{name: "waiting", req: &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Wait: &wait}, started: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			type result struct {
+				res *ExecResult
+				err error
+			}
+			done := make(chan result, 1)
+			go func() {
+				res, err := m.Exec(ctx, tt.req)
+				done <- result{res, err}
+			}()
+
+			var r result
+			select {
+			case r = <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("exec outlived its context")
+			}
+
+			if !errors.Is(r.err, context.DeadlineExceeded) {
+				t.Errorf("got %v, want %v", r.err, context.DeadlineExceeded)
+			}
+			if started := r.res != nil; started != tt.started {
+				t.Fatalf("got result %+v, want one: %v", r.res, tt.started)
+			}
+			if tt.started {
+				defer syscall.Kill(r.res.PID, syscall.SIGKILL)
+				if _, err := m.Status(context.Background(), r.res.PID); err != nil {
+					t.Errorf("process %d not reachable: %v", r.res.PID, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExecUploadFailureKillsProcess(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	upload := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("upload broke off")))
+
+	res, err := m.ExecUpload(context.Background(), &proto.ExecProcessRequest{Args: []string{"cat"}}, upload)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if res == nil {
+		t.Fatal("got no result for the started process")
+	}
+
+	wres, err := m.Wait(context.Background(), res.PID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wres.ExitCode != 128+int(syscall.SIGKILL) {
+		t.Errorf("got exit code %d, want the process killed", wres.ExitCode)
+	}
+}
+
+func TestAttachWaitsForInput(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{Args: []string{"sleep", "0.1"}, Stdin: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A caller that never sends input, whose read Attach has to cut short.
+	var reading, reads atomic.Int32
+	recv := func(ctx context.Context) (*proto.AttachRequest, error) {
+		reads.Add(1)
+		reading.Add(1)
+		defer reading.Add(-1)
+
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	err = m.Attach(context.Background(), res.PID, recv, func(*proto.OutputChunk) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := reading.Load(); n != 0 {
+		t.Errorf("%d reads still in progress after Attach returned", n)
+	}
+	if n := reads.Load(); n != 1 {
+		t.Errorf("got %d reads, want 1", n)
+	}
+}
@@ -0,0 +1,153 @@
+package goproc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsNamespace = "goproc"
+
+type serverMetrics struct {
+	registry     *prometheus.Registry
+	execsStarted prometheus.Counter
+	execsFailed  prometheus.Counter
+	rpcDuration  *prometheus.HistogramVec
+	exitCodes    *prometheus.CounterVec
+}
+
+func newServerMetrics(mgr *Manager) *serverMetrics {
+	m := &serverMetrics{
+		registry: prometheus.NewRegistry(),
+		execsStarted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "execs_started_total",
+			Help:      "Number of processes started.",
+		}),
+		execsFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "execs_failed_total",
+			Help:      "Number of exec requests that did not start a process.",
+		}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "rpc_duration_seconds",
+			Help:      "Latency of gRPC calls by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		exitCodes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "process_exits_total",
+			Help:      "Number of process exits by command name and exit code.",
+		}, []string{"command", "exit_code"}),
+	}
+
+	m.registry.MustRegister(
+		m.execsStarted,
+		m.execsFailed,
+		m.rpcDuration,
+		m.exitCodes,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "processes_running",
+			Help:      "Number of managed processes that are still running.",
+		}, func() float64 {
+			running := 0
+			mgr.processMap.Range(func(key, value interface{}) bool {
+				if value.(*Process).Running() {
+					running++
+				}
+				return true
+			})
+			return float64(running)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "processes_retained",
+			Help:      "Number of processes held in the process table, running or not.",
+		}, func() float64 {
+			retained := 0
+			mgr.processMap.Range(func(key, value interface{}) bool {
+				retained++
+				return true
+			})
+			return float64(retained)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "output_buffered_bytes",
+			Help:      "Bytes of stdout and stderr captured but not yet read.",
+		}, func() float64 {
+			buffered := 0
+			mgr.processMap.Range(func(key, value interface{}) bool {
+				buffered += value.(*Process).BufferedBytes()
+				return true
+			})
+			return float64(buffered)
+		}),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+func (m *serverMetrics) processExited(p *Process) {
+	command := filepath.Base(p.cmd.Args[0])
+	m.exitCodes.WithLabelValues(command, strconv.Itoa(p.ExitCode())).Inc()
+}
+
+func (m *serverMetrics) observe(fullMethod string, start time.Time, err error) {
+	m.rpcDuration.WithLabelValues(path.Base(fullMethod), status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
+
+func (m *serverMetrics) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observe(info.FullMethod, start, err)
+	return resp, err
+}
+
+func (m *serverMetrics) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.observe(info.FullMethod, start, err)
+	return err
+}
+
+// serve exposes the registry on /metrics until ctx is cancelled.
+func (m *serverMetrics) serve(ctx context.Context, port uint) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	log.Info().Msgf("Serving metrics @%s/metrics", srv.Addr)
+
+	err := srv.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
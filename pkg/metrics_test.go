@@ -0,0 +1,145 @@
+package goproc
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// metricValue returns the value of the counter or gauge name with labels, or
+// -1 if the registry has no such metric.
+func metricValue(t *testing.T, m *serverMetrics, name string, labels map[string]string) float64 {
+	t.Helper()
+
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != metricsNamespace+"_"+name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if !hasLabels(metric, labels) {
+				continue
+			}
+			if metric.Counter != nil {
+				return metric.GetCounter().GetValue()
+			}
+			return metric.GetGauge().GetValue()
+		}
+	}
+
+	return -1
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, label := range metric.GetLabel() {
+		if want, ok := labels[label.GetName()]; ok {
+			if label.GetValue() != want {
+				return false
+			}
+			matched++
+		}
+	}
+
+	return matched == len(labels)
+}
+
+func TestExecMetrics(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	ctx := context.Background()
+	wait := true
+
+	for _, args := range [][]string{{"true"}, {"sh", "-c", "exit 7"}, {"sh", "-c", "exit 7"}} {
+		if _, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: args, Wait: &wait}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"/nonexistent/binary"}}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := m.Exec(ctx, &proto.ExecProcessRequest{Args: []string{"sleep", "60"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{name: "execs_started_total", want: 4},
+		{name: "execs_failed_total", want: 1},
+		{name: "process_exits_total", labels: map[string]string{"command": "true", "exit_code": "0"}, want: 1},
+		{name: "process_exits_total", labels: map[string]string{"command": "sh", "exit_code": "7"}, want: 2},
+		{name: "processes_running", want: 1},
+		{name: "processes_retained", want: 4},
+	}
+
+	for _, tt := range tests {
+		if got := metricValue(t, m.metrics, tt.name, tt.labels); got != tt.want {
+			t.Errorf("%s%v = %v, want %v", tt.name, tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsServe(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- m.metrics.serve(ctx, uint(port)) }()
+
+	var body string
+	deadline := time.Now().Add(5 * time.Second)
+	for body == "" {
+		resp, err := http.Get("http://" + lis.Addr().String() + "/metrics")
+		if err != nil {
+			if time.Now().After(deadline) {
+				t.Fatal(err)
+			}
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(data)
+	}
+
+	for _, name := range []string{"goproc_execs_started_total", "goproc_processes_running", "go_goroutines"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("/metrics has no %s", name)
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("got %v, want nil once cancelled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("still serving after cancel")
+	}
+}
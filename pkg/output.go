@@ -0,0 +1,144 @@
+package goproc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+const defaultOutputFileMode = 0644
+
+// checkOutputTargets validates the stdout and stderr targets of a request.
+func checkOutputTargets(req *proto.ExecProcessRequest) error {
+	if req.Tty && (isCustomTarget(req.Stdout) || isCustomTarget(req.Stderr)) {
+		return fmt.Errorf("%w: a terminal's output is always buffered", ErrInvalidOutputTarget)
+	}
+
+	if req.Stdout.GetType() == proto.OutputTargetType_OUTPUT_TARGET_MERGE {
+		return fmt.Errorf("%w: only stderr can be merged", ErrInvalidOutputTarget)
+	}
+
+	if req.Pipeline.GetStdout() != nil && isCustomTarget(req.Stdout) {
+		return fmt.Errorf("%w: stdout and pipeline.stdout are mutually exclusive", ErrInvalidOutputTarget)
+	}
+
+	if isFileWithoutPath(req.Stdout) {
+		return fmt.Errorf("%w: stdout file has no path", ErrInvalidOutputTarget)
+	}
+	if isFileWithoutPath(req.Stderr) {
+		return fmt.Errorf("%w: stderr file has no path", ErrInvalidOutputTarget)
+	}
+
+	return nil
+}
+
+func isFileWithoutPath(target *proto.OutputTarget) bool {
+	return target.GetType() == proto.OutputTargetType_OUTPUT_TARGET_FILE && target.GetFile().GetPath() == ""
+}
+
+func isCustomTarget(target *proto.OutputTarget) bool {
+	switch target.GetType() {
+	case proto.OutputTargetType_OUTPUT_TARGET_UNSPECIFIED, proto.OutputTargetType_OUTPUT_TARGET_BUFFER:
+		return false
+	}
+
+	return true
+}
+
+// openOutput returns where the process writes stdout and stderr, following
+// the output targets. Buffered output goes to the output buffers or, when
+// logDir is set, to files under it rather than pipes, so it can keep being
+// written if the server restarts; those files are tailed into the buffers once
+// the process has started. Files opened here are closed by the returned func
+// once the process has them; output files come from p.files.
+func (p *Process) openOutput() (io.Writer, io.Writer, func(), error) {
+	var files []*os.File
+	closeOutput := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	var stdoutFile, stderrFile *os.File
+	if p.files != nil {
+		stdoutFile, stderrFile = p.files.stdout, p.files.stderr
+	}
+
+	stdout, stdoutPath, err := p.openTarget(p.stdoutTarget, stdoutFile, p.stdoutBuf, ".stdout", &files)
+	if err != nil {
+		closeOutput()
+		return nil, nil, nil, err
+	}
+
+	stderr := stdout
+	stderrPath := ""
+	if p.stderrTarget.GetType() != proto.OutputTargetType_OUTPUT_TARGET_MERGE {
+		stderr, stderrPath, err = p.openTarget(p.stderrTarget, stderrFile, p.stderrBuf, ".stderr", &files)
+		if err != nil {
+			closeOutput()
+			return nil, nil, nil, err
+		}
+	}
+
+	p.stdoutPath = stdoutPath
+	p.stderrPath = stderrPath
+
+	return stdout, stderr, closeOutput, nil
+}
+
+// openTarget returns the writer for one output stream, and the path of its
+// log file if it is buffered through one. A nil writer discards the output.
+// file is the already open file of a file target.
+func (p *Process) openTarget(target *proto.OutputTarget, file *os.File, buf *SafeBuffer, logSuffix string, files *[]*os.File) (io.Writer, string, error) {
+	switch target.GetType() {
+	case proto.OutputTargetType_OUTPUT_TARGET_DISCARD:
+		return nil, "", nil
+	case proto.OutputTargetType_OUTPUT_TARGET_FILE:
+		return file, "", nil
+	}
+
+	if p.logDir == "" {
+		return buf, "", nil
+	}
+
+	path := filepath.Join(p.logDir, p.id+logSuffix)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, "", err
+	}
+	*files = append(*files, f)
+
+	return f, path, nil
+}
+
+func openRedirect(ctx context.Context, dir string, r *proto.Redirect) (*os.File, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if r.Append {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	mode := os.FileMode(defaultOutputFileMode)
+	if r.Mode != 0 {
+		mode = os.FileMode(r.Mode).Perm()
+	}
+
+	f, err := openFile(ctx, resolvePath(dir, r.Path), flags, mode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOutputTarget, err)
+	}
+
+	return f, nil
+}
+
+// resolvePath resolves a path relative to a process's working directory.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) || dir == "" {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
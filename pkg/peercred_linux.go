@@ -0,0 +1,36 @@
+//go:build linux
+
+package goproc
+
+import (
+	"net"
+	"syscall"
+
+	"google.golang.org/grpc/credentials"
+)
+
+func peerCredentials(conn *net.UnixConn) (*PeerCredAuthInfo, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	var ucred *syscall.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		ucred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if credErr != nil {
+		return nil, credErr
+	}
+
+	return &PeerCredAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		PID:            ucred.Pid,
+		UID:            ucred.Uid,
+		GID:            ucred.Gid,
+	}, nil
+}
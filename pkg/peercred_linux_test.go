@@ -0,0 +1,62 @@
+package goproc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/beam-cloud/goproc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnixSocketPeerCredentials(t *testing.T) {
+	uid := os.Getuid()
+
+	tests := []struct {
+		name        string
+		allowedUIDs []int
+		code        codes.Code
+	}{
+		{name: "any uid", code: codes.NotFound},
+		{name: "allowed uid", allowedUIDs: []int{uid + 1, uid}, code: codes.NotFound},
+		{name: "other uids", allowedUIDs: []int{uid + 1}, code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "goproc.sock")
+			cs := newTestServer(t, GoProcConfig{UnixSocket: UnixSocketConfig{Path: path, UID: -1, GID: -1, AllowedUIDs: tt.allowedUIDs}})
+
+			unary, stream, closeInterceptors, err := cs.interceptors()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer closeInterceptors()
+
+			lis, err := listenUnix(cs.cfg.UnixSocket)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// No TCP credentials: the socket needs none.
+			s := grpc.NewServer(grpc.Creds(newListenerCredentials(nil)), grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+			proto.RegisterGoProcServer(s, cs)
+			go s.Serve(lis)
+			defer s.Stop()
+
+			c, err := NewGoProcClient(context.Background(), unixScheme+path, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Cleanup()
+
+			_, err = c.Status(context.Background(), 999999)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package goproc
+
+import (
+	"net"
+)
+
+func peerCredentials(conn *net.UnixConn) (*PeerCredAuthInfo, error) {
+	return nil, ErrPeerCredUnsupported
+}
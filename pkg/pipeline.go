@@ -0,0 +1,274 @@
+package goproc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// pipeline is a process made of several commands, each stage's stdout
+// connected to the next stage's stdin. The stages share a process group led by
+// the first stage, so a signal to the process reaches all of them.
+type pipeline struct {
+	stages []*pipelineStage
+}
+
+type pipelineStage struct {
+	cmd *exec.Cmd
+
+	// exitCode is set once the stage has exited, under Process.mu.
+	exitCode int
+	exited   bool
+}
+
+// execCommands returns the commands an exec request runs, checking that a
+// pipeline is well formed.
+func execCommands(req *proto.ExecProcessRequest) ([][]string, error) {
+	if req.Pipeline == nil {
+		if len(req.Args) == 0 {
+			return nil, ErrNoCommand
+		}
+		return [][]string{req.Args}, nil
+	}
+
+	switch {
+	case len(req.Args) > 0:
+		return nil, fmt.Errorf("%w: args and pipeline are mutually exclusive", ErrInvalidPipeline)
+	case req.Tty:
+		return nil, fmt.Errorf("%w: pipelines can't run in a terminal", ErrInvalidPipeline)
+	case len(req.Pipeline.Stages) == 0:
+		return nil, ErrNoCommand
+	case req.Pipeline.Stdout != nil && req.Pipeline.Stdout.Path == "":
+		return nil, fmt.Errorf("%w: stdout redirect has no path", ErrInvalidPipeline)
+	}
+
+	commands := make([][]string, 0, len(req.Pipeline.Stages))
+	for i, stage := range req.Pipeline.Stages {
+		if len(stage.Args) == 0 {
+			return nil, fmt.Errorf("%w: stage %d has no command", ErrInvalidPipeline, i)
+		}
+		if stage.Stderr != nil && stage.Stderr.Path == "" {
+			return nil, fmt.Errorf("%w: stage %d stderr redirect has no path", ErrInvalidPipeline, i)
+		}
+		commands = append(commands, stage.Args)
+	}
+
+	return commands, nil
+}
+
+func newPipeline(req *proto.Pipeline, cwd string, env []string) *pipeline {
+	pl := &pipeline{}
+	for _, stage := range req.Stages {
+		pl.stages = append(pl.stages, &pipelineStage{
+			cmd:      newCommand(stage.Args, cwd, env),
+			exitCode: -1,
+		})
+	}
+
+	return pl
+}
+
+// execPipeline runs pl as the process. Its pid is that of the first stage.
+func (p *Process) execPipeline(pl *pipeline, wait bool) (int, error) {
+	p.pipeline = pl
+	p.cmd = pl.stages[0].cmd
+	return p.run(wait)
+}
+
+// startPipeline wires the stages together and starts them in order. The first
+// stage reads stdin unless stdin is kept open. Output not redirected to one of
+// p.files goes to stdout and stderr.
+func (p *Process) startPipeline(stdin io.Reader, stdout, stderr io.Writer) error {
+	pl := p.pipeline
+	first := pl.stages[0].cmd
+	last := pl.stages[len(pl.stages)-1].cmd
+
+	// The pipes handed to the stages are closed here once they have started,
+	// so that only the stages hold them and see end-of-file when the stage
+	// before them exits.
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	if p.openStdin {
+		pipe, err := first.StdinPipe()
+		if err != nil {
+			return err
+		}
+		p.stdin = pipe
+	} else if stdin != nil {
+		first.Stdin = stdin
+	}
+
+	last.Stdout = stdout
+	if p.files.stdout != nil {
+		last.Stdout = p.files.stdout
+	}
+
+	for i, stage := range pl.stages {
+		stage.cmd.Stderr = stderr
+		if f := p.files.stageStderr[i]; f != nil {
+			stage.cmd.Stderr = f
+		}
+
+		if i+1 < len(pl.stages) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				return err
+			}
+			files = append(files, r, w)
+			stage.cmd.Stdout = w
+			pl.stages[i+1].cmd.Stdin = r
+		}
+	}
+
+	for i, stage := range pl.stages {
+		stage.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		if i > 0 {
+			stage.cmd.SysProcAttr.Pgid = first.Process.Pid
+		}
+
+		if err := stage.cmd.Start(); err != nil {
+			if i > 0 {
+				syscall.Kill(-first.Process.Pid, syscall.SIGKILL)
+				for _, started := range pl.stages[:i] {
+					started.cmd.Wait()
+				}
+			}
+			return fmt.Errorf("stage %d: %w", i, startError(err))
+		}
+	}
+
+	return nil
+}
+
+// waitPipeline waits for every stage and returns the exit code of the last
+// one to fail, or 0 if they all succeeded.
+func (p *Process) waitPipeline() int {
+	var wg sync.WaitGroup
+	for _, stage := range p.pipeline.stages {
+		wg.Add(1)
+		go func(stage *pipelineStage) {
+			defer wg.Done()
+			exitCode := p.waitCommand(stage.cmd)
+
+			p.mu.Lock()
+			stage.exitCode = exitCode
+			stage.exited = true
+			p.mu.Unlock()
+		}(stage)
+	}
+	wg.Wait()
+
+	stages := p.pipeline.stages
+	for i := len(stages) - 1; i >= 0; i-- {
+		if stages[i].exitCode != 0 {
+			return stages[i].exitCode
+		}
+	}
+
+	return 0
+}
+
+// commands returns the commands the process runs: its pipeline's stages, or
+// just cmd.
+func (p *Process) commands() []*exec.Cmd {
+	if p.pipeline == nil {
+		return []*exec.Cmd{p.cmd}
+	}
+
+	cmds := make([]*exec.Cmd, 0, len(p.pipeline.stages))
+	for _, stage := range p.pipeline.stages {
+		cmds = append(cmds, stage.cmd)
+	}
+
+	return cmds
+}
+
+// command describes what the process runs, with pipeline stages joined by
+// " | ".
+func (p *Process) command() string {
+	cmds := p.commands()
+
+	parts := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		parts = append(parts, cmd.String())
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// StageExitCodes returns the exit code of every stage of a pipeline, -1 for
+// stages still running, or nil if the process is not a pipeline.
+func (p *Process) StageExitCodes() []int {
+	if p.pipeline == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	codes := make([]int, 0, len(p.pipeline.stages))
+	for _, stage := range p.pipeline.stages {
+		codes = append(codes, stage.exitCode)
+	}
+
+	return codes
+}
+
+func (p *Process) stageInfos() []*proto.PipelineStageInfo {
+	if p.pipeline == nil {
+		return nil
+	}
+
+	// Stages of a restored pipeline that is no longer running never report
+	// their exit, so they count as exited with an unknown code.
+	running := p.Running()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	infos := make([]*proto.PipelineStageInfo, 0, len(p.pipeline.stages))
+	for _, stage := range p.pipeline.stages {
+		info := &proto.PipelineStageInfo{Cmd: stage.cmd.String()}
+		if stage.cmd.Process != nil {
+			info.Pid = int32(stage.cmd.Process.Pid)
+		}
+		if stage.exited || !running {
+			exitCode := int32(stage.exitCode)
+			info.ExitCode = &exitCode
+		}
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// signal sends sig to the process or, for a pipeline, to its process group.
+func (p *Process) signal(sig os.Signal) error {
+	if p.pipeline == nil {
+		return p.cmd.Process.Signal(sig)
+	}
+
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return errors.New("unsupported signal")
+	}
+
+	err := syscall.Kill(-p.pid, s)
+	if errors.Is(err, syscall.ESRCH) {
+		return os.ErrProcessDone
+	}
+
+	return err
+}
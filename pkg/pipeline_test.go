@@ -0,0 +1,128 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestPipelineExitCodes(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+
+	exit := func(code string) *proto.PipelineStage {
+		return &proto.PipelineStage{Args: []string{"sh", "-c", "cat > /dev/null; exit " + code}}
+	}
+
+	tests := []struct {
+		name       string
+		stages     []*proto.PipelineStage
+		exitCode   int
+		stageCodes []int
+	}{
+		{name: "all succeed", stages: []*proto.PipelineStage{exit("0"), exit("0"), exit("0")}, exitCode: 0, stageCodes: []int{0, 0, 0}},
+		{name: "first fails", stages: []*proto.PipelineStage{exit("3"), exit("0")}, exitCode: 3, stageCodes: []int{3, 0}},
+		{name: "last fails", stages: []*proto.PipelineStage{exit("0"), exit("4")}, exitCode: 4, stageCodes: []int{0, 4}},
+		{name: "last failure wins", stages: []*proto.PipelineStage{exit("2"), exit("5"), exit("0")}, exitCode: 5, stageCodes: []int{2, 5, 0}},
+		{name: "single stage", stages: []*proto.PipelineStage{exit("7")}, exitCode: 7, stageCodes: []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait := true
+			res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{
+				Pipeline:  &proto.Pipeline{Stages: tt.stages},
+				StdinData: []byte("input"),
+				Wait:      &wait,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res.ExitCode != tt.exitCode {
+				t.Errorf("got exit code %d, want %d", res.ExitCode, tt.exitCode)
+			}
+			if !slices.Equal(res.StageExitCodes, tt.stageCodes) {
+				t.Errorf("got stage exit codes %v, want %v", res.StageExitCodes, tt.stageCodes)
+			}
+		})
+	}
+}
+
+func TestPipelineRedirects(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in")
+	if err := os.WriteFile(in, []byte("b\na\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	wait := true
+	res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{
+		Cwd: dir,
+		Pipeline: &proto.Pipeline{
+			Stages: []*proto.PipelineStage{
+				{Args: []string{"sort"}},
+				{Args: []string{"sh", "-c", "cat; echo oops >&2"}, Stderr: &proto.Redirect{Path: "err"}},
+			},
+			StdinFile: "in",
+			Stdout:    &proto.Redirect{Path: "out"},
+		},
+		Wait: &wait,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExitCode != 0 {
+		t.Fatalf("got exit code %d", res.ExitCode)
+	}
+
+	for name, want := range map[string]string{"out": "a\nb\n", "err": "oops\n"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s holds %q, want %q", name, data, want)
+		}
+	}
+}
+
+func TestExecCommands(t *testing.T) {
+	stage := func(args ...string) *proto.PipelineStage {
+		return &proto.PipelineStage{Args: args}
+	}
+
+	tests := []struct {
+		name string
+		req  *proto.ExecProcessRequest
+		want [][]string
+		err  error
+	}{
+		{name: "args", req: &proto.ExecProcessRequest{Args: []string{"ls", "-l"}}, want: [][]string{{"ls", "-l"}}},
+		{name: "no command", req: &proto.ExecProcessRequest{}, err: ErrNoCommand},
+		{name: "pipeline", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{Stages: []*proto.PipelineStage{stage("ls"), stage("wc", "-l")}}}, want: [][]string{{"ls"}, {"wc", "-l"}}},
+		{name: "no stages", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{}}, err: ErrNoCommand},
+		{name: "args and pipeline", req: &proto.ExecProcessRequest{Args: []string{"ls"}, Pipeline: &proto.Pipeline{Stages: []*proto.PipelineStage{stage("ls")}}}, err: ErrInvalidPipeline},
+		{name: "tty", req: &proto.ExecProcessRequest{Tty: true, Pipeline: &proto.Pipeline{Stages: []*proto.PipelineStage{stage("ls")}}}, err: ErrInvalidPipeline},
+		{name: "empty stage", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{Stages: []*proto.PipelineStage{stage("ls"), stage()}}}, err: ErrInvalidPipeline},
+		{name: "stdout without path", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{Stages: []*proto.PipelineStage{stage("ls")}, Stdout: &proto.Redirect{}}}, err: ErrInvalidPipeline},
+		{name: "stderr without path", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{Stages: []*proto.PipelineStage{{Args: []string{"ls"}, Stderr: &proto.Redirect{}}}}}, err: ErrInvalidPipeline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := execCommands(tt.req)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if !slices.EqualFunc(commands, tt.want, slices.Equal[[]string]) {
+				t.Errorf("got %v, want %v", commands, tt.want)
+			}
+		})
+	}
+}
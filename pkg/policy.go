@@ -0,0 +1,395 @@
+package goproc
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// PolicyViolationError is returned by Exec when a request is blocked by the
+// configured policy. Rule names the deny rule that matched, or is empty when
+// the request matched none of the allow rules.
+type PolicyViolationError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *PolicyViolationError) Error() string {
+	if e.Rule == "" {
+		return fmt.Sprintf("policy violation: %s", e.Reason)
+	}
+
+	return fmt.Sprintf("policy violation: blocked by rule %q: %s", e.Rule, e.Reason)
+}
+
+func (e *PolicyViolationError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// policyRequest is the part of an exec request a policy is evaluated against.
+type policyRequest struct {
+	executable string
+	args       []string
+	cwd        string
+	envNames   []string
+	paths      []string
+}
+
+type compiledRule struct {
+	name         string
+	executables  []string
+	args         []*regexp.Regexp
+	cwdPrefixes  []string
+	envNames     []string
+	pathPrefixes []string
+}
+
+type policy struct {
+	allow []*compiledRule
+	deny  []*compiledRule
+}
+
+func newPolicy(cfg PolicyConfig) (*policy, error) {
+	p := &policy{}
+
+	for _, r := range cfg.Allow {
+		rule, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		p.allow = append(p.allow, rule)
+	}
+
+	for _, r := range cfg.Deny {
+		rule, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		p.deny = append(p.deny, rule)
+	}
+
+	return p, nil
+}
+
+func compileRule(r PolicyRule) (*compiledRule, error) {
+	rule := &compiledRule{
+		name:        r.Name,
+		executables: r.Executables,
+		envNames:    r.EnvNames,
+	}
+
+	for _, pattern := range r.Executables {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("policy rule %q: invalid executable pattern %q: %w", r.Name, pattern, err)
+		}
+	}
+
+	for _, pattern := range r.EnvNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("policy rule %q: invalid env name pattern %q: %w", r.Name, pattern, err)
+		}
+	}
+
+	for _, expr := range r.Args {
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("policy rule %q: invalid args pattern %q: %w", r.Name, expr, err)
+		}
+		rule.args = append(rule.args, re)
+	}
+
+	for _, prefix := range r.CwdPrefixes {
+		rule.cwdPrefixes = append(rule.cwdPrefixes, resolvePolicyPath(prefix))
+	}
+
+	for _, prefix := range r.PathPrefixes {
+		rule.pathPrefixes = append(rule.pathPrefixes, resolvePolicyPath(prefix))
+	}
+
+	return rule, nil
+}
+
+// newPolicyRequest resolves the executable the same way exec.Command will, so
+// rules are matched against the real path rather than what the caller typed.
+// paths are the files the request opens, relative to cwd.
+func newPolicyRequest(args []string, cwd string, env []string, paths []string) *policyRequest {
+	cwd = resolvePolicyPath(cwd)
+
+	executable := args[0]
+	if strings.Contains(executable, "/") {
+		if !filepath.IsAbs(executable) {
+			executable = filepath.Join(cwd, executable)
+		}
+	} else if resolved, err := exec.LookPath(executable); err == nil {
+		executable = resolved
+	}
+
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	if abs, err := filepath.Abs(executable); err == nil {
+		executable = abs
+	}
+
+	envNames := make([]string, 0, len(env))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		envNames = append(envNames, name)
+	}
+
+	resolvedPaths := make([]string, 0, len(paths))
+	for _, p := range paths {
+		resolvedPaths = append(resolvedPaths, resolvePolicyPath(resolvePath(cwd, p)))
+	}
+
+	return &policyRequest{
+		executable: executable,
+		args:       args[1:],
+		cwd:        cwd,
+		envNames:   envNames,
+		paths:      resolvedPaths,
+	}
+}
+
+// requestedPaths returns the files an exec request has the server open on the
+// process's behalf.
+func requestedPaths(req *proto.ExecProcessRequest) []string {
+	var paths []string
+	if req.StdinFile != "" {
+		paths = append(paths, req.StdinFile)
+	}
+
+	for _, name := range sortedKeys(req.EnvFiles) {
+		paths = append(paths, req.EnvFiles[name])
+	}
+
+	for _, target := range []*proto.OutputTarget{req.Stdout, req.Stderr} {
+		if target.GetType() == proto.OutputTargetType_OUTPUT_TARGET_FILE {
+			paths = append(paths, target.GetFile().GetPath())
+		}
+	}
+
+	if req.Pipeline != nil {
+		if req.Pipeline.StdinFile != "" {
+			paths = append(paths, req.Pipeline.StdinFile)
+		}
+		if req.Pipeline.Stdout != nil {
+			paths = append(paths, req.Pipeline.Stdout.Path)
+		}
+		for _, stage := range req.Pipeline.Stages {
+			if stage.Stderr != nil {
+				paths = append(paths, stage.Stderr.Path)
+			}
+		}
+	}
+
+	return paths
+}
+
+// resolvePolicyPath makes p absolute against the server's working directory
+// and resolves symlinks in as much of it as exists, so that "../../etc", a
+// link into /etc or a file yet to be created under such a link is matched
+// under /etc. An empty p is the server's working directory.
+func resolvePolicyPath(p string) string {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return filepath.Clean(p)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+
+	dir := filepath.Dir(abs)
+	if dir == abs {
+		return abs
+	}
+
+	return filepath.Join(resolvePolicyPath(dir), filepath.Base(abs))
+}
+
+// check returns a *PolicyViolationError if the request is blocked. Deny rules
+// are evaluated first; if any allow rules are configured, the request must
+// then match at least one of them.
+func (p *policy) check(req *policyRequest) error {
+	for _, rule := range p.deny {
+		if reason, ok := rule.denies(req); ok {
+			return &PolicyViolationError{Rule: rule.name, Reason: reason}
+		}
+	}
+
+	if len(p.allow) == 0 {
+		return nil
+	}
+
+	for _, rule := range p.allow {
+		if rule.allows(req) {
+			return nil
+		}
+	}
+
+	return &PolicyViolationError{Reason: fmt.Sprintf("%s is not permitted by any allow rule", req.executable)}
+}
+
+// denies reports whether every criterion set on a deny rule matches: the
+// executable, any argument, the cwd, any environment variable name, or any
+// file the request opens.
+func (r *compiledRule) denies(req *policyRequest) (string, bool) {
+	var reasons []string
+
+	if len(r.executables) > 0 {
+		if !matchAnyGlob(r.executables, req.executable) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("executable %s", req.executable))
+	}
+
+	if len(r.args) > 0 {
+		arg, ok := firstMatchingArg(r.args, req.args)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("argument %q", arg))
+	}
+
+	if len(r.cwdPrefixes) > 0 {
+		if !hasAnyPathPrefix(r.cwdPrefixes, req.cwd) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("cwd %s", req.cwd))
+	}
+
+	if len(r.envNames) > 0 {
+		name, ok := firstMatchingName(r.envNames, req.envNames)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("env %s", name))
+	}
+
+	if len(r.pathPrefixes) > 0 {
+		p, ok := firstPathWithPrefix(r.pathPrefixes, req.paths)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("path %s", p))
+	}
+
+	if len(reasons) == 0 {
+		return "", false
+	}
+
+	return strings.Join(reasons, ", "), true
+}
+
+// allows reports whether a request stays within an allow rule: the executable
+// and cwd must match, and every argument, environment variable name and file
+// the request opens must be covered by one of the rule's patterns.
+func (r *compiledRule) allows(req *policyRequest) bool {
+	if len(r.executables) > 0 && !matchAnyGlob(r.executables, req.executable) {
+		return false
+	}
+
+	if len(r.args) > 0 {
+		for _, arg := range req.args {
+			if !matchAnyRegexp(r.args, arg) {
+				return false
+			}
+		}
+	}
+
+	if len(r.cwdPrefixes) > 0 && !hasAnyPathPrefix(r.cwdPrefixes, req.cwd) {
+		return false
+	}
+
+	if len(r.envNames) > 0 {
+		for _, name := range req.envNames {
+			if !matchAnyGlob(r.envNames, name) {
+				return false
+			}
+		}
+	}
+
+	if len(r.pathPrefixes) > 0 {
+		for _, p := range req.paths {
+			if !hasAnyPathPrefix(r.pathPrefixes, p) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchAnyGlob matches s against path.Match patterns. Patterns without a slash
+// are matched against the last path element, so "rm" matches "/usr/bin/rm".
+func matchAnyGlob(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		target := s
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(s)
+		}
+
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAnyRegexp(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstMatchingArg(patterns []*regexp.Regexp, args []string) (string, bool) {
+	for _, arg := range args {
+		if matchAnyRegexp(patterns, arg) {
+			return arg, true
+		}
+	}
+
+	return "", false
+}
+
+func firstMatchingName(patterns []string, names []string) (string, bool) {
+	for _, name := range names {
+		if matchAnyGlob(patterns, name) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func firstPathWithPrefix(prefixes []string, paths []string) (string, bool) {
+	for _, p := range paths {
+		if hasAnyPathPrefix(prefixes, p) {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+func hasAnyPathPrefix(prefixes []string, p string) bool {
+	for _, prefix := range prefixes {
+		if p == prefix || prefix == "/" || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,232 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	cfg := PolicyConfig{
+		Allow: []PolicyRule{
+			{Name: "tools", Executables: []string{"/usr/bin/*", "/bin/*"}, CwdPrefixes: []string{"/tmp"}, PathPrefixes: []string{"/tmp"}},
+			{Name: "echo", Executables: []string{"echo"}, Args: []string{"[a-z]+"}, EnvNames: []string{"APP_*"}},
+		},
+		Deny: []PolicyRule{
+			{Name: "no-recursive-rm", Executables: []string{"rm"}, Args: []string{"-[a-zA-Z]*r[a-zA-Z]*"}},
+			{Name: "no-preload", EnvNames: []string{"LD_*"}},
+			{Name: "no-etc", CwdPrefixes: []string{"/etc"}},
+			{Name: "no-etc-files", PathPrefixes: []string{"/etc"}},
+		},
+	}
+
+	p, err := newPolicy(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		cwd   string
+		env   []string
+		paths []string
+		rule  string
+		ok    bool
+	}{
+		{name: "allowed", args: []string{"/bin/ls", "-l"}, cwd: "/tmp", ok: true},
+		{name: "allowed below cwd prefix", args: []string{"/bin/ls"}, cwd: "/tmp/a/b", ok: true},
+		{name: "cwd outside allow rule", args: []string{"/bin/ls"}, cwd: "/var"},
+		{name: "cwd with prefix as name prefix only", args: []string{"/bin/ls"}, cwd: "/tmpfoo"},
+		{name: "denied args", args: []string{"rm", "-rf", "/"}, cwd: "/tmp", rule: "no-recursive-rm"},
+		{name: "deny needs every criterion", args: []string{"rm", "file"}, cwd: "/tmp", ok: true},
+		{name: "denied env", args: []string{"/bin/ls"}, cwd: "/tmp", env: []string{"LD_PRELOAD=x.so"}, rule: "no-preload"},
+		{name: "denied cwd", args: []string{"/bin/ls"}, cwd: "/etc/ssl", rule: "no-etc"},
+		{name: "denied relative cwd", args: []string{"/bin/ls"}, cwd: strings.Repeat("../", 64) + "etc", rule: "no-etc"},
+		{name: "allow rule args", args: []string{"echo", "hi"}, env: []string{"APP_MODE=1"}, ok: true},
+		{name: "allow rule args not covered", args: []string{"echo", "HI"}},
+		{name: "allow rule env not covered", args: []string{"echo", "hi"}, env: []string{"HOME=/root"}},
+		{name: "allowed path", args: []string{"/bin/ls"}, cwd: "/tmp", paths: []string{"/tmp/out.log", "err.log"}, ok: true},
+		{name: "path outside allow rule", args: []string{"/bin/ls"}, cwd: "/tmp", paths: []string{"/tmp/out.log", "/var/log/out.log"}},
+		{name: "allow rule without path prefixes", args: []string{"echo", "hi"}, paths: []string{"/var/log/out.log"}, ok: true},
+		{name: "denied path", args: []string{"/bin/ls"}, cwd: "/tmp", paths: []string{"/etc/cron.d/job"}, rule: "no-etc-files"},
+		{name: "denied relative path", args: []string{"/bin/ls"}, cwd: "/tmp", paths: []string{"../etc/cron.d/job"}, rule: "no-etc-files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.check(newPolicyRequest(tt.args, tt.cwd, tt.env, tt.paths))
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var policyErr *PolicyViolationError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("got %v, want a policy violation", err)
+			}
+			if !errors.Is(err, ErrPolicyViolation) {
+				t.Errorf("%v does not match ErrPolicyViolation", err)
+			}
+			if policyErr.Rule != tt.rule {
+				t.Errorf("got rule %q, want %q", policyErr.Rule, tt.rule)
+			}
+		})
+	}
+}
+
+func TestPolicyResolvesCwd(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret")
+	if err := os.Mkdir(secret, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(secret, link); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newPolicy(PolicyConfig{Deny: []PolicyRule{{Name: "no-secret", CwdPrefixes: []string{secret}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	relative, err := filepath.Rel(wd, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cwd := range []string{secret, link, filepath.Join(link, "."), relative} {
+		if err := p.check(newPolicyRequest([]string{"/bin/true"}, cwd, nil, nil)); !errors.Is(err, ErrPolicyViolation) {
+			t.Errorf("cwd %s: got %v, want a policy violation", cwd, err)
+		}
+	}
+
+	if err := p.check(newPolicyRequest([]string{"/bin/true"}, dir, nil, nil)); err != nil {
+		t.Errorf("cwd %s: unexpected error: %v", dir, err)
+	}
+}
+
+func TestPolicyResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret")
+	if err := os.Mkdir(secret, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newPolicy(PolicyConfig{Deny: []PolicyRule{{Name: "no-secret", PathPrefixes: []string{secret}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cwd    string
+		path   string
+		denied bool
+	}{
+		{name: "new file", cwd: dir, path: filepath.Join(secret, "new.log"), denied: true},
+		{name: "new file through link", cwd: dir, path: filepath.Join(dir, "link", "new.log"), denied: true},
+		{name: "new directory through link", cwd: dir, path: filepath.Join(dir, "link", "a", "b", "new.log"), denied: true},
+		{name: "relative to cwd", cwd: dir, path: "link/new.log", denied: true},
+		{name: "relative to linked cwd", cwd: filepath.Join(dir, "link"), path: "new.log", denied: true},
+		{name: "outside", cwd: secret, path: "../new.log"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.check(newPolicyRequest([]string{"/bin/true"}, tt.cwd, nil, []string{tt.path}))
+			if tt.denied != errors.Is(err, ErrPolicyViolation) {
+				t.Errorf("got %v, want denied %v", err, tt.denied)
+			}
+		})
+	}
+}
+
+func TestExecChecksPaths(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret")
+	if err := os.Mkdir(secret, 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestManager(t, GoProcConfig{Policy: PolicyConfig{Deny: []PolicyRule{{Name: "no-secret", PathPrefixes: []string{secret}}}}})
+	target := filepath.Join(secret, "out.log")
+	input := filepath.Join(secret, "input")
+	if err := os.WriteFile(input, []byte("secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  *proto.ExecProcessRequest
+	}{
+		{name: "stdout file", req: &proto.ExecProcessRequest{Args: []string{"true"}, Stdout: &proto.OutputTarget{
+			Type: proto.OutputTargetType_OUTPUT_TARGET_FILE,
+			File: &proto.Redirect{Path: target},
+		}}},
+		{name: "stderr file relative to cwd", req: &proto.ExecProcessRequest{Args: []string{"true"}, Cwd: dir, Stderr: &proto.OutputTarget{
+			Type: proto.OutputTargetType_OUTPUT_TARGET_FILE,
+			File: &proto.Redirect{Path: "secret/out.log"},
+		}}},
+		{name: "pipeline stdout", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{
+			Stages: []*proto.PipelineStage{{Args: []string{"true"}}},
+			Stdout: &proto.Redirect{Path: target},
+		}}},
+		{name: "pipeline stage stderr", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{
+			Stages: []*proto.PipelineStage{{Args: []string{"true"}}, {Args: []string{"true"}, Stderr: &proto.Redirect{Path: target}}},
+		}}},
+		{name: "stdin file", req: &proto.ExecProcessRequest{Args: []string{"cat"}, StdinFile: input}},
+		{name: "stdin file relative to cwd", req: &proto.ExecProcessRequest{Args: []string{"cat"}, Cwd: dir, StdinFile: "secret/input"}},
+		{name: "pipeline stdin file", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{
+			Stages:    []*proto.PipelineStage{{Args: []string{"cat"}}},
+			StdinFile: input,
+		}}},
+		{name: "env file", req: &proto.ExecProcessRequest{Args: []string{"true"}, EnvFiles: map[string]string{"TOKEN": input}}},
+		{name: "env file relative to cwd", req: &proto.ExecProcessRequest{Args: []string{"true"}, Cwd: dir, EnvFiles: map[string]string{"TOKEN": "secret/input"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.Exec(context.Background(), tt.req); !errors.Is(err, ErrPolicyViolation) {
+				t.Fatalf("got %v, want a policy violation", err)
+			}
+			if _, err := os.Stat(target); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("%s was created: %v", target, err)
+			}
+		})
+	}
+}
+
+func TestNewPolicyRejectsInvalidPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		rule PolicyRule
+	}{
+		{name: "executable", rule: PolicyRule{Executables: []string{"["}}},
+		{name: "env name", rule: PolicyRule{EnvNames: []string{"["}}},
+		{name: "args", rule: PolicyRule{Args: []string{"("}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newPolicy(PolicyConfig{Deny: []PolicyRule{tt.rule}}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -2,12 +2,51 @@ package goproc
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"os/exec"
-	"strings"
 	"sync"
+	"syscall"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/creack/pty"
+)
+
+const (
+	defaultTerminalRows = 24
+	defaultTerminalCols = 80
+
+	// outputDrainTimeout bounds how long output is read after the process
+	// exits, since a background child can keep a terminal open indefinitely.
+	outputDrainTimeout = time.Second
+	logPollInterval    = 50 * time.Millisecond
+
+	endOfTransmission = "\x04"
 )
 
+// ProcessState is the lifecycle state of a process.
+type ProcessState string
+
+const (
+	ProcessRunning ProcessState = "running"
+	ProcessExited  ProcessState = "exited"
+	// ProcessLost is a process that was running when the server stopped and
+	// had vanished by the time it restarted, so its exit code is unknown.
+	ProcessLost ProcessState = "lost"
+)
+
+// TerminalSize is the size of a process's pseudo-terminal in characters.
+type TerminalSize struct {
+	Rows uint16
+	Cols uint16
+}
+
 type Process struct {
 	ctx       context.Context
 	pid       int
@@ -15,87 +54,362 @@ type Process struct {
 	cmd       *exec.Cmd
 	stdoutBuf *SafeBuffer
 	stderrBuf *SafeBuffer
+	done      chan struct{}
+	onStart   func(*Process)
+	onExit    func(*Process)
 	mu        sync.Mutex
+
+	// openStdin, stdinData, files, terminal, reportEnv and the output targets
+	// are set before Exec. files are closed once the process has started.
+	// terminal runs the process in a pseudo-terminal of that size. reportEnv
+	// is the environment reported in place of cmd.Env.
+	openStdin    bool
+	stdinData    []byte
+	files        *execFiles
+	terminal     *TerminalSize
+	reportEnv    []string
+	stdoutTarget *proto.OutputTarget
+	stderrTarget *proto.OutputTarget
+
+	stdin         io.WriteCloser
+	stdinMu       sync.Mutex
+	tty           *os.File
+	exited        chan struct{}
+	outputDrained chan struct{}
+
+	// name and labels are set before Exec and never change.
+	name   string
+	labels map[string]string
+
+	// id names the process in the registry. When logDir is set before Exec,
+	// output is written to files under it instead of pipes.
+	id         string
+	startTime  uint64
+	startedAt  time.Time
+	logDir     string
+	stdoutPath string
+	stderrPath string
+	lost       bool
+
+	// reaper is set before Exec in init mode. reaped receives the exit status
+	// of each command if the reaper collects it before monitor does.
+	reaper *reaper
+	reaped map[int]chan syscall.WaitStatus
+
+	// pipeline is set for a pipeline, whose first stage is cmd.
+	pipeline *pipeline
 }
 
 func NewProcess(ctx context.Context) (*Process, error) {
-	return &Process{ctx: ctx, pid: -1, exitCode: -1, mu: sync.Mutex{}}, nil
+	id, err := newProcessID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Process{
+		ctx:      ctx,
+		id:       id,
+		pid:      -1,
+		exitCode: -1,
+		done:     make(chan struct{}),
+		exited:   make(chan struct{}),
+		mu:       sync.Mutex{},
+	}, nil
+}
+
+func newProcessID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
 }
 
 func (p *Process) Exec(args []string, cwd string, env []string, wait bool) (int, error) {
+	p.cmd = newCommand(args, cwd, env)
+	return p.run(wait)
+}
+
+func newCommand(args []string, cwd string, env []string) *exec.Cmd {
 	cmd := exec.CommandContext(context.Background(), args[0], args[1:]...)
 	cmd.Dir = cwd
 	cmd.Env = env
+	// Don't let a background child holding the output pipes keep the process
+	// from being reported as exited.
+	cmd.WaitDelay = outputDrainTimeout
+
+	return cmd
+}
 
-	p.cmd = cmd
+// run starts p.cmd, or the pipeline it leads, and monitors it.
+func (p *Process) run(wait bool) (int, error) {
 	p.stdoutBuf = &SafeBuffer{}
 	p.stderrBuf = &SafeBuffer{}
-	p.cmd.Stdout = p.stdoutBuf
-	p.cmd.Stderr = p.stderrBuf
 
-	err := p.cmd.Start()
+	err := p.startManaged()
 	if err != nil {
 		return -1, err
 	}
 
 	p.pid = p.cmd.Process.Pid
+	p.startedAt = time.Now()
+	p.startTime, _ = processStartTime(p.pid)
+
+	if p.onStart != nil {
+		p.onStart(p)
+	}
+
+	// A single goroutine owns cmd.Wait; everyone else waits on p.done.
+	go p.monitor()
 
 	if wait {
-		// Wait synchronously
-		err = p.cmd.Wait()
+		<-p.done
+	}
+
+	return p.pid, nil
+}
+
+func (p *Process) startManaged() error {
+	if p.reaper != nil {
+		return p.reaper.start(p)
+	}
+
+	return p.start()
+}
+
+func (p *Process) start() error {
+	defer p.files.close()
+
+	if p.terminal != nil {
+		return p.startTerminal()
+	}
+
+	stdout, stderr, closeOutput, err := p.openOutput()
+	if err != nil {
+		return err
+	}
+	defer closeOutput()
+
+	stdin := p.openInput()
+	if p.pipeline != nil {
+		err = p.startPipeline(stdin, stdout, stderr)
+	} else {
+		err = p.startCommand(stdin, stdout, stderr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if p.logDir != "" {
+		return p.tailLogs()
+	}
+
+	return nil
+}
+
+func (p *Process) startCommand(stdin io.Reader, stdout, stderr io.Writer) error {
+	if p.openStdin {
+		pipe, err := p.cmd.StdinPipe()
 		if err != nil {
-			return p.pid, err
+			return err
 		}
-		p.exitCode = p.cmd.ProcessState.ExitCode()
-	} else {
-		// Monitor the process in background
-		go func() {
-			err := p.cmd.Wait()
-			if err != nil {
-				p.exitCode = 1
-				return
-			}
+		p.stdin = pipe
+	} else if stdin != nil {
+		p.cmd.Stdin = stdin
+	}
 
-			if p.cmd.ProcessState != nil {
-				p.exitCode = p.cmd.ProcessState.ExitCode()
+	p.cmd.Stdout = stdout
+	p.cmd.Stderr = stderr
+
+	return startError(p.cmd.Start())
+}
+
+// startError marks a missing executable given by path with
+// ErrExecutableNotFound. One looked up in PATH already fails with an
+// *exec.Error.
+func startError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) && pathErr.Op == "fork/exec" && errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrExecutableNotFound, err)
+	}
+
+	return err
+}
+
+// tailLogs copies the log files into the output buffers as they grow, until
+// the process has exited and the files are read to the end.
+func (p *Process) tailLogs() error {
+	logs := []struct {
+		path string
+		buf  *SafeBuffer
+	}{
+		{p.stdoutPath, p.stdoutBuf},
+		{p.stderrPath, p.stderrBuf},
+	}
+
+	var files []*os.File
+	var bufs []*SafeBuffer
+	for _, l := range logs {
+		if l.path == "" {
+			continue
+		}
+
+		f, err := os.Open(l.path)
+		if err != nil {
+			for _, f := range files {
+				f.Close()
 			}
-		}()
+			return err
+		}
+
+		files = append(files, f)
+		bufs = append(bufs, l.buf)
 	}
 
-	return p.pid, nil
+	if len(files) == 0 {
+		return nil
+	}
+
+	p.outputDrained = make(chan struct{})
+
+	go func() {
+		defer close(p.outputDrained)
+
+		var wg sync.WaitGroup
+		for i := range files {
+			wg.Add(1)
+			go func(f *os.File, buf *SafeBuffer) {
+				defer wg.Done()
+				tailFile(f, buf, p.exited)
+			}(files[i], bufs[i])
+		}
+		wg.Wait()
+	}()
+
+	return nil
 }
 
-func (p *Process) Wait() (int, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+func tailFile(f *os.File, buf *SafeBuffer, stop <-chan struct{}) {
+	defer f.Close()
 
-	if p.cmd == nil {
-		return -1, ErrProcessNotFound
+	for {
+		if _, err := io.Copy(buf, f); err != nil {
+			return
+		}
+
+		select {
+		case <-stop:
+			io.Copy(buf, f)
+			return
+		case <-time.After(logPollInterval):
+		}
 	}
+}
 
-	// If we already have an exit code, the process was already waited on
-	if p.exitCode != -1 {
-		return p.exitCode, nil
+// startTerminal starts the process on a new pseudo-terminal. Everything the
+// process writes to it is captured as stdout.
+func (p *Process) startTerminal() error {
+	tty, err := pty.StartWithSize(p.cmd, &pty.Winsize{Rows: p.terminal.Rows, Cols: p.terminal.Cols})
+	if err != nil {
+		return startError(err)
 	}
 
-	err := p.cmd.Wait()
+	p.tty = tty
+	p.stdin = tty
+	p.outputDrained = make(chan struct{})
 
-	// Set exit code if wait succeeded
-	if err == nil && p.cmd.ProcessState != nil {
-		p.exitCode = p.cmd.ProcessState.ExitCode()
+	go func() {
+		defer close(p.outputDrained)
+		// Reads fail with EIO once the last holder of the terminal exits.
+		io.Copy(p.stdoutBuf, tty)
+	}()
+
+	return nil
+}
+
+func (p *Process) monitor() {
+	if p.pipeline != nil {
+		p.finish(p.waitPipeline())
+		return
 	}
 
-	// If error is "already waited", that's ok - get exit code from ProcessState
-	if err != nil && strings.Contains(err.Error(), "wait") && p.cmd.ProcessState != nil {
-		p.exitCode = p.cmd.ProcessState.ExitCode()
-		return p.exitCode, nil
+	p.finish(p.waitCommand(p.cmd))
+}
+
+// waitCommand waits for cmd, one of the process's commands, and returns its
+// exit code.
+func (p *Process) waitCommand(cmd *exec.Cmd) int {
+	err := cmd.Wait()
+
+	if p.reaper != nil {
+		pid := cmd.Process.Pid
+		if errors.Is(err, syscall.ECHILD) {
+			return exitCodeOfStatus(p.reaper.wait(p, pid))
+		}
+		p.reaper.forget(p, pid)
 	}
 
-	if err != nil {
-		return p.exitCode, err
+	return exitCodeOf(cmd.ProcessState, err)
+}
+
+// finish records the exit of the process once its remaining output has been
+// read, then wakes up waiters.
+func (p *Process) finish(exitCode int) {
+	close(p.exited)
+
+	if p.outputDrained != nil {
+		select {
+		case <-p.outputDrained:
+		case <-time.After(outputDrainTimeout):
+		}
+	}
+
+	if p.tty != nil {
+		p.tty.Close()
+	}
+
+	p.mu.Lock()
+	p.exitCode = exitCode
+	p.mu.Unlock()
+
+	close(p.done)
+
+	if p.onExit != nil {
+		p.onExit(p)
+	}
+}
+
+// exitCodeOf returns the exit code of a finished process, using the shell
+// convention of 128+signal for processes terminated by a signal.
+func exitCodeOf(state *os.ProcessState, err error) int {
+	if state == nil {
+		return 1
+	}
+
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok {
+		return exitCodeOfStatus(ws)
+	}
+
+	return state.ExitCode()
+}
+
+func exitCodeOfStatus(ws syscall.WaitStatus) int {
+	if ws.Signaled() {
+		return 128 + int(ws.Signal())
 	}
 
-	return p.exitCode, nil
+	return ws.ExitStatus()
+}
+
+func (p *Process) Wait() (int, error) {
+	if p.cmd == nil {
+		return -1, ErrProcessNotFound
+	}
+
+	<-p.done
+
+	return p.ExitCode(), nil
 }
 
 func (p *Process) Kill() error {
@@ -106,7 +420,16 @@ func (p *Process) Kill() error {
 		return ErrProcessNotFound
 	}
 
-	return p.cmd.Process.Kill()
+	if !p.Running() {
+		return ErrProcessExited
+	}
+
+	err := p.signal(os.Kill)
+	if errors.Is(err, os.ErrProcessDone) {
+		return ErrProcessExited
+	}
+
+	return err
 }
 
 func (p *Process) Signal(sig os.Signal) error {
@@ -117,22 +440,113 @@ func (p *Process) Signal(sig os.Signal) error {
 		return ErrProcessNotFound
 	}
 
-	return p.cmd.Process.Signal(sig)
+	if !p.Running() {
+		return ErrProcessExited
+	}
+
+	err := p.signal(sig)
+	if errors.Is(err, os.ErrProcessDone) {
+		return ErrProcessExited
+	}
+
+	return err
 }
 
-func (p *Process) Running() bool {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+// WriteStdin writes data to the process's stdin. It fails with ErrStdinNotOpen
+// unless the process was started with stdin open or on a terminal.
+func (p *Process) WriteStdin(data []byte) (int, error) {
+	p.stdinMu.Lock()
+	defer p.stdinMu.Unlock()
+
+	if p.stdin == nil {
+		return 0, ErrStdinNotOpen
+	}
+
+	if !p.Running() {
+		return 0, ErrProcessExited
+	}
+
+	n, err := p.stdin.Write(data)
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.EIO) || errors.Is(err, os.ErrClosed) {
+		return n, ErrProcessExited
+	}
+
+	return n, err
+}
+
+// CloseStdin closes the process's stdin. On a terminal it sends end-of-file
+// instead, since closing the terminal would hang up the process.
+func (p *Process) CloseStdin() error {
+	p.stdinMu.Lock()
+	defer p.stdinMu.Unlock()
+
+	if p.stdin == nil {
+		return ErrStdinNotOpen
+	}
+
+	if p.tty != nil {
+		_, err := io.WriteString(p.tty, endOfTransmission)
+		return err
+	}
+
+	err := p.stdin.Close()
+	p.stdin = nil
+	return err
+}
+
+// Resize changes the size of the process's terminal.
+func (p *Process) Resize(size TerminalSize) error {
+	if p.tty == nil {
+		return ErrNoTerminal
+	}
+
+	if !p.Running() {
+		return ErrProcessExited
+	}
+
+	return pty.Setsize(p.tty, &pty.Winsize{Rows: size.Rows, Cols: size.Cols})
+}
+
+// HasStdin reports whether the process's stdin can be written.
+func (p *Process) HasStdin() bool {
+	p.stdinMu.Lock()
+	defer p.stdinMu.Unlock()
+	return p.stdin != nil
+}
+
+func (p *Process) State() ProcessState {
+	switch {
+	case p.Running():
+		return ProcessRunning
+	case p.Lost():
+		return ProcessLost
+	}
+
+	return ProcessExited
+}
+
+// Lost reports whether the process was running when the server last stopped
+// and had vanished by the time it restarted.
+func (p *Process) Lost() bool {
+	return p.lost
+}
 
+// HasTerminal reports whether the process runs in a pseudo-terminal.
+func (p *Process) HasTerminal() bool {
+	return p.tty != nil
+}
+
+func (p *Process) Running() bool {
 	if p.cmd == nil {
 		return false
 	}
 
-	if p.cmd.ProcessState == nil {
+	select {
+	case <-p.done:
+		return false
+	default:
 		return true
 	}
-
-	return !p.cmd.ProcessState.Exited()
 }
 
 func (p *Process) ExitCode() int {
@@ -146,6 +560,15 @@ func (p *Process) ExitCode() int {
 	return p.exitCode
 }
 
+// BufferedBytes returns the amount of output captured but not yet read.
+func (p *Process) BufferedBytes() int {
+	if p.cmd == nil {
+		return 0
+	}
+
+	return p.stdoutBuf.Len() + p.stderrBuf.Len()
+}
+
 func (p *Process) Logs() string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -0,0 +1,468 @@
+package goproc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	reattachPollInterval = time.Second
+
+	// registrySaveDelay is how long exits are collected before the registry
+	// is saved, so a burst of them is written once.
+	registrySaveDelay = 200 * time.Millisecond
+)
+
+// processRecord is what the registry keeps about a process. StartTime is the
+// start time from /proc/<pid>/stat, used to tell the process apart from a
+// later one reusing its pid. Stages holds the path and args of every stage of
+// a pipeline, whose first stage is Path and Args.
+type processRecord struct {
+	ID         string            `json:"id"`
+	PID        int               `json:"pid"`
+	StartTime  uint64            `json:"start_time"`
+	StartedAt  time.Time         `json:"started_at"`
+	Path       string            `json:"path"`
+	Args       []string          `json:"args"`
+	Stages     [][]string        `json:"stages,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Cwd        string            `json:"cwd"`
+	Env        []string          `json:"env"`
+	StdoutPath string            `json:"stdout_path,omitempty"`
+	StderrPath string            `json:"stderr_path,omitempty"`
+	State      ProcessState      `json:"state"`
+	ExitCode   int               `json:"exit_code"`
+}
+
+type registryFile struct {
+	Processes []processRecord `json:"processes"`
+}
+
+// registry persists process records to a state file so that a restarted
+// server can reattach to the processes it left running. Starts are saved
+// right away and exits in batches, the file being replaced atomically each
+// time. Only the most recent maxExited records of processes no longer running
+// are kept.
+type registry struct {
+	path      string
+	logDir    string
+	maxExited int
+	mu        sync.Mutex
+	records   map[string]processRecord
+
+	// dirty wakes the goroutine saving changes; done is closed by close,
+	// after which changes are saved right away.
+	dirty  chan struct{}
+	done   chan struct{}
+	saved  chan struct{}
+	closed bool
+}
+
+func newRegistry(cfg RegistryConfig) (*registry, error) {
+	if cfg.Path == "" {
+		return nil, ErrRegistryPathNotSet
+	}
+
+	logDir := cfg.LogDir
+	if logDir == "" {
+		logDir = filepath.Join(filepath.Dir(cfg.Path), "logs")
+	}
+
+	if err := os.MkdirAll(logDir, 0700); err != nil {
+		return nil, err
+	}
+
+	r := &registry{
+		path:      cfg.Path,
+		logDir:    logDir,
+		maxExited: cfg.MaxExited,
+		records:   make(map[string]processRecord),
+		dirty:     make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		saved:     make(chan struct{}),
+	}
+
+	data, err := os.ReadFile(cfg.Path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		var rf registryFile
+		if err := json.Unmarshal(data, &rf); err != nil {
+			return nil, fmt.Errorf("invalid registry file %s: %w", cfg.Path, err)
+		}
+
+		for _, rec := range rf.Processes {
+			r.records[rec.ID] = rec
+		}
+	}
+
+	go r.saveChanges()
+
+	return r, nil
+}
+
+// restore rebuilds the processes recorded by an earlier server instance, oldest
+// first, past the retention limit. Processes still running with the same start
+// time are reattached and monitored; those that vanished are marked lost.
+func (r *registry) restore(onExit func(*Process)) ([]*Process, error) {
+	r.prune()
+
+	r.mu.Lock()
+	records := make([]processRecord, 0, len(r.records))
+	for _, rec := range r.records {
+		records = append(records, rec)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].StartedAt.Before(records[j].StartedAt)
+	})
+
+	processes := make([]*Process, 0, len(records))
+	for _, rec := range records {
+		proc := restoreProcess(rec, onExit)
+
+		switch {
+		case proc.Running():
+			log.Info().Int("pid", rec.PID).Strs("args", rec.Args).Msg("Reattached to process")
+		case proc.Lost():
+			log.Warn().Int("pid", rec.PID).Strs("args", rec.Args).Msg("Process vanished while the server was down")
+		}
+
+		processes = append(processes, proc)
+
+		r.mu.Lock()
+		r.records[proc.id] = recordOf(proc)
+		r.mu.Unlock()
+	}
+
+	// Persist lost states right away rather than on the next change.
+	return processes, r.save()
+}
+
+// prune drops the oldest records of processes no longer running beyond
+// maxExited, along with their log files, and returns them.
+func (r *registry) prune() []processRecord {
+	if r.maxExited <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	var exited []processRecord
+	for _, rec := range r.records {
+		if rec.State != ProcessRunning {
+			exited = append(exited, rec)
+		}
+	}
+
+	if len(exited) <= r.maxExited {
+		r.mu.Unlock()
+		return nil
+	}
+
+	sort.Slice(exited, func(i, j int) bool {
+		return exited[i].StartedAt.Before(exited[j].StartedAt)
+	})
+
+	pruned := exited[:len(exited)-r.maxExited]
+	for _, rec := range pruned {
+		delete(r.records, rec.ID)
+	}
+	r.mu.Unlock()
+
+	for _, rec := range pruned {
+		for _, path := range []string{rec.StdoutPath, rec.StderrPath} {
+			if path == "" {
+				continue
+			}
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Warn().Err(err).Str("path", path).Msg("Failed to remove process log")
+			}
+		}
+	}
+
+	return pruned
+}
+
+// restoreProcess rebuilds a Process from a record. Its output is read back
+// from the log files, if any.
+func restoreProcess(rec processRecord, onExit func(*Process)) *Process {
+	p := &Process{
+		id:         rec.ID,
+		pid:        rec.PID,
+		exitCode:   -1,
+		startTime:  rec.StartTime,
+		startedAt:  rec.StartedAt,
+		name:       rec.Name,
+		labels:     rec.Labels,
+		stdoutPath: rec.StdoutPath,
+		stderrPath: rec.StderrPath,
+		stdoutBuf:  &SafeBuffer{},
+		stderrBuf:  &SafeBuffer{},
+		done:       make(chan struct{}),
+		exited:     make(chan struct{}),
+		cmd: &exec.Cmd{
+			Path: rec.Path,
+			Args: rec.Args,
+			Dir:  rec.Cwd,
+			Env:  rec.Env,
+		},
+	}
+	p.restorePipeline(rec.Stages)
+
+	if rec.State == ProcessRunning && isSameProcess(rec.PID, rec.StartTime) {
+		proc, err := os.FindProcess(rec.PID)
+		if err == nil {
+			p.cmd.Process = proc
+			p.onExit = onExit
+			p.restoreLogs()
+			go p.monitorReattached()
+			return p
+		}
+	}
+
+	exitCode := rec.ExitCode
+	if rec.State != ProcessExited {
+		p.lost = true
+		exitCode = -1
+	}
+
+	p.restoreLogs()
+	p.finish(exitCode)
+
+	return p
+}
+
+func (p *Process) restoreLogs() {
+	if p.stdoutPath == "" && p.stderrPath == "" {
+		return
+	}
+
+	if err := p.tailLogs(); err != nil {
+		log.Warn().Err(err).Int("pid", p.pid).Msg("Failed to open process logs")
+	}
+}
+
+// monitorReattached polls a process started by an earlier server instance.
+// It is not a child of this one, so its exit status can't be collected and
+// its exit code is reported as -1.
+func (p *Process) monitorReattached() {
+	for isSameProcess(p.pid, p.startTime) {
+		time.Sleep(reattachPollInterval)
+	}
+
+	p.finish(-1)
+}
+
+func isSameProcess(pid int, startTime uint64) bool {
+	current, err := processStartTime(pid)
+	return err == nil && current == startTime
+}
+
+// processStartTime returns the start time of pid in clock ticks after boot,
+// field 22 of /proc/<pid>/stat. Zombies count as gone, since a process that is
+// no longer ours is reaped by someone else.
+func processStartTime(pid int) (uint64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	// The command name in field 2 may contain spaces, so count fields from
+	// the closing parenthesis; starttime is the 20th field after it.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return 0, fmt.Errorf("malformed /proc/%d/stat", pid)
+	}
+
+	fields := bytes.Fields(data[end+1:])
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("malformed /proc/%d/stat", pid)
+	}
+
+	if state := string(fields[0]); state == "Z" || state == "X" {
+		return 0, fmt.Errorf("process %d has exited", pid)
+	}
+
+	return strconv.ParseUint(string(fields[19]), 10, 64)
+}
+
+func recordOf(p *Process) processRecord {
+	return processRecord{
+		ID:         p.id,
+		PID:        p.pid,
+		StartTime:  p.startTime,
+		StartedAt:  p.startedAt,
+		Path:       p.cmd.Path,
+		Args:       p.cmd.Args,
+		Cwd:        p.cmd.Dir,
+		Env:        p.reportedEnv(),
+		StdoutPath: p.stdoutPath,
+		StderrPath: p.stderrPath,
+		Stages:     stageRecords(p),
+		Name:       p.name,
+		Labels:     p.labels,
+		State:      p.State(),
+		ExitCode:   p.ExitCode(),
+	}
+}
+
+func stageRecords(p *Process) [][]string {
+	if p.pipeline == nil {
+		return nil
+	}
+
+	stages := make([][]string, 0, len(p.pipeline.stages))
+	for _, stage := range p.pipeline.stages {
+		stages = append(stages, append([]string{stage.cmd.Path}, stage.cmd.Args[1:]...))
+	}
+
+	return stages
+}
+
+// restorePipeline rebuilds the stages of a restored pipeline. Only the first
+// stage is monitored, and signals still reach the whole process group.
+func (p *Process) restorePipeline(stages [][]string) {
+	if len(stages) == 0 {
+		return
+	}
+
+	p.pipeline = &pipeline{}
+	for i, args := range stages {
+		cmd := p.cmd
+		if i > 0 {
+			cmd = &exec.Cmd{Path: args[0], Args: args, Dir: p.cmd.Dir, Env: p.cmd.Env}
+		}
+		p.pipeline.stages = append(p.pipeline.stages, &pipelineStage{cmd: cmd, exitCode: -1})
+	}
+}
+
+// update records the current state of p and has the registry saved. A start
+// is saved before update returns, and so before the pid is handed out, so
+// that a crash never loses track of a running process; exits are saved in
+// the next batch, after pruning the records of processes no longer running.
+// It returns the pruned records.
+func (r *registry) update(p *Process) []processRecord {
+	r.mu.Lock()
+	r.records[p.id] = recordOf(p)
+	closed := r.closed
+	r.mu.Unlock()
+
+	if p.Running() {
+		r.saveOrLog()
+		return nil
+	}
+
+	pruned := r.prune()
+	if closed {
+		r.saveOrLog()
+		return pruned
+	}
+
+	select {
+	case r.dirty <- struct{}{}:
+	default:
+	}
+
+	return pruned
+}
+
+// saveChanges saves the registry once changes have been collected for
+// registrySaveDelay, until close.
+func (r *registry) saveChanges() {
+	defer close(r.saved)
+
+	for {
+		select {
+		case <-r.dirty:
+		case <-r.done:
+			return
+		}
+
+		select {
+		case <-time.After(registrySaveDelay):
+		case <-r.done:
+		}
+
+		r.saveOrLog()
+	}
+}
+
+// close saves pending changes. Later changes are saved as they happen.
+func (r *registry) close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	r.mu.Unlock()
+
+	close(r.done)
+	<-r.saved
+	r.saveOrLog()
+}
+
+func (r *registry) saveOrLog() {
+	if err := r.save(); err != nil {
+		log.Error().Err(err).Str("path", r.path).Msg("Failed to save process registry")
+	}
+}
+
+// save writes the registry to a temporary file and renames it over the state
+// file, so a crash never leaves a partially written registry behind.
+func (r *registry) save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rf := registryFile{Processes: make([]processRecord, 0, len(r.records))}
+	for _, rec := range r.records {
+		rf.Processes = append(rf.Processes, rec)
+	}
+
+	sort.Slice(rf.Processes, func(i, j int) bool {
+		return rf.Processes[i].StartedAt.Before(rf.Processes[j].StartedAt)
+	})
+
+	data, err := json.MarshalIndent(rf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), r.path)
+}
@@ -0,0 +1,216 @@
+package goproc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+func TestRegistryPrune(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := func(id string, minute int, state ProcessState) processRecord {
+		return processRecord{ID: id, PID: minute + 100, StartedAt: start.Add(time.Duration(minute) * time.Minute), State: state}
+	}
+
+	records := []processRecord{
+		record("a", 0, ProcessExited),
+		record("b", 1, ProcessRunning),
+		record("c", 2, ProcessLost),
+		record("d", 3, ProcessExited),
+		record("e", 4, ProcessExited),
+	}
+
+	tests := []struct {
+		name      string
+		maxExited int
+		pruned    []string
+		kept      []string
+	}{
+		{name: "unlimited", maxExited: 0, kept: []string{"a", "b", "c", "d", "e"}},
+		{name: "under the limit", maxExited: 5, kept: []string{"a", "b", "c", "d", "e"}},
+		{name: "at the limit", maxExited: 4, kept: []string{"a", "b", "c", "d", "e"}},
+		{name: "oldest first", maxExited: 2, pruned: []string{"a", "c"}, kept: []string{"b", "d", "e"}},
+		{name: "running kept", maxExited: 1, pruned: []string{"a", "c", "d"}, kept: []string{"b", "e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r := &registry{maxExited: tt.maxExited, records: make(map[string]processRecord)}
+			for _, rec := range records {
+				rec.StdoutPath = filepath.Join(dir, rec.ID+".stdout")
+				if err := os.WriteFile(rec.StdoutPath, nil, 0600); err != nil {
+					t.Fatal(err)
+				}
+				r.records[rec.ID] = rec
+			}
+
+			var pruned []string
+			for _, rec := range r.prune() {
+				pruned = append(pruned, rec.ID)
+			}
+			if !slices.Equal(pruned, tt.pruned) {
+				t.Errorf("pruned %v, want %v", pruned, tt.pruned)
+			}
+
+			var kept []string
+			for id := range r.records {
+				kept = append(kept, id)
+			}
+			slices.Sort(kept)
+			if !slices.Equal(kept, tt.kept) {
+				t.Errorf("kept %v, want %v", kept, tt.kept)
+			}
+
+			for _, rec := range records {
+				_, err := os.Stat(filepath.Join(dir, rec.ID+".stdout"))
+				if removed := errors.Is(err, fs.ErrNotExist); removed != slices.Contains(tt.pruned, rec.ID) {
+					t.Errorf("log of %s removed: %v", rec.ID, removed)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistrySavesStartsAtOnceAndExitsInBatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	r, err := newRegistry(RegistryConfig{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.close()
+
+	var procs []*Process
+	for i := 0; i < 100; i++ {
+		proc, err := NewProcess(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		proc.pid = 1000 + i
+		proc.cmd = newCommand([]string{"true"}, "", nil)
+		r.update(proc)
+		procs = append(procs, proc)
+
+		if n := len(readRegistry(t, path).Processes); n != i+1 {
+			t.Fatalf("registry holds %d records after %d starts", n, i+1)
+		}
+	}
+
+	for _, proc := range procs {
+		close(proc.done)
+		r.update(proc)
+	}
+
+	exited := func() int {
+		var n int
+		for _, rec := range readRegistry(t, path).Processes {
+			if rec.State == ProcessExited {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := exited(); n != 0 {
+		t.Fatalf("%d exits saved before the save delay", n)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for exited() != len(procs) {
+		if time.Now().After(deadline) {
+			t.Fatalf("exits not saved")
+		}
+		time.Sleep(registrySaveDelay / 4)
+	}
+}
+
+func TestRegistryRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registry.json")
+	m := newTestManager(t, GoProcConfig{Registry: RegistryConfig{Enabled: true, Path: path, MaxExited: 2}})
+	sub, _, err := m.events.subscribe(nil, []proto.ProcessEventType{proto.ProcessEventType_PROCESS_EVENT_REMOVED}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.events.unsubscribe(sub)
+
+	var pids []int
+	for i := 0; i < 4; i++ {
+		res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{Args: []string{"echo", "hi"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Wait(context.Background(), res.PID)
+		pids = append(pids, res.PID)
+	}
+
+	// The exit of the last process prunes the first ones once it is handled.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := m.Status(context.Background(), pids[1])
+		if errors.Is(err, ErrProcessNotFound) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("process %d not pruned: %v", pids[1], err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, pid := range pids[2:] {
+		if _, err := m.Status(context.Background(), pid); err != nil {
+			t.Errorf("process %d: %v", pid, err)
+		}
+	}
+
+	for _, pid := range pids[:2] {
+		select {
+		case ev := <-sub.ch:
+			if int(ev.Process.Pid) != pid {
+				t.Errorf("got removed event for %d, want %d", ev.Process.Pid, pid)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no removed event for %d", pid)
+		}
+	}
+
+	m.Shutdown()
+
+	if n := len(readRegistry(t, path).Processes); n != 2 {
+		t.Errorf("registry holds %d records, want 2", n)
+	}
+
+	logs, err := os.ReadDir(filepath.Join(dir, "logs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 4 {
+		t.Errorf("got %d log files, want the 4 of the processes kept", len(logs))
+	}
+}
+
+func readRegistry(t *testing.T, path string) registryFile {
+	t.Helper()
+
+	var rf registryFile
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return rf
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &rf); err != nil {
+		t.Fatal(err)
+	}
+
+	return rf
+}
@@ -8,44 +8,142 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
-	"sync"
 	"syscall"
+	"time"
 
 	"github.com/beam-cloud/goproc/proto"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// minKeepaliveInterval is the shortest client keepalive interval accepted
+// before the server closes the connection for pinging too often.
+const minKeepaliveInterval = 10 * time.Second
+
+// GoProcServer serves a Manager over gRPC, and over HTTP when the gateway is
+// enabled.
 type GoProcServer struct {
 	cfg GoProcConfig
 	proto.UnimplementedGoProcServer
-	processMap sync.Map
+	manager *Manager
 }
 
 func NewGoProcServer(cfg GoProcConfig) (*GoProcServer, error) {
-	return &GoProcServer{cfg: cfg}, nil
+	manager, err := NewManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoProcServer{cfg: cfg, manager: manager}, nil
+}
+
+// Manager returns the manager behind the server, for use in the same process.
+func (cs *GoProcServer) Manager() *Manager {
+	return cs.manager
 }
 
 func (cs *GoProcServer) StartServer(ctx context.Context, port uint) error {
-	addr := fmt.Sprintf(":%d", port)
+	if port == 0 && cs.cfg.UnixSocket.Path == "" {
+		return ErrNoListeners
+	}
+
+	var tcpCreds credentials.TransportCredentials
+	if port != 0 {
+		creds, err := serverCredentials(cs.cfg.TLS)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to configure transport credentials")
+			return err
+		}
+
+		if creds.Info().SecurityProtocol == "insecure" {
+			log.Warn().Msg("TLS is disabled, serving plaintext gRPC")
+		}
 
-	localListener, err := net.Listen("tcp", addr)
+		tcpCreds = creds
+	}
+
+	unary, stream, closeInterceptors, err := cs.interceptors()
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to listen on %s", addr)
 		return err
 	}
+	defer closeInterceptors()
 
 	maxMessageSize := cs.cfg.GRPCMessageSizeBytes
-	s := grpc.NewServer(
+	opts := []grpc.ServerOption{
+		grpc.Creds(newListenerCredentials(tcpCreds)),
 		grpc.MaxRecvMsgSize(maxMessageSize),
 		grpc.MaxSendMsgSize(maxMessageSize),
 		grpc.NumStreamWorkers(uint32(runtime.NumCPU())),
-	)
-	proto.RegisterGoProcServer(s, cs)
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             minKeepaliveInterval,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+
+	s := grpc.NewServer(opts...)
+	healthServer := cs.registerServices(s)
+
+	if port != 0 {
+		addr := fmt.Sprintf(":%d", port)
+
+		localListener, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to listen on %s", addr)
+			return err
+		}
+
+		log.Info().Msgf("Running @%s, cfg: %+v", addr, cs.cfg)
+		go s.Serve(localListener)
+	}
+
+	if cs.cfg.UnixSocket.Path != "" {
+		unixListener, err := listenUnix(cs.cfg.UnixSocket)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to listen on %s", cs.cfg.UnixSocket.Path)
+			s.Stop()
+			return err
+		}
 
-	log.Info().Msgf("Running @%s, cfg: %+v", addr, cs.cfg)
+		log.Info().Msgf("Running @unix://%s", cs.cfg.UnixSocket.Path)
+		go s.Serve(unixListener)
+	}
 
-	go s.Serve(localListener)
+	serveCtx, cancelServe := context.WithCancel(ctx)
+	defer cancelServe()
+
+	go cs.manager.forwardSignals(serveCtx, cs.manager.forwarded)
+
+	if cs.cfg.Metrics.Enabled {
+		go func() {
+			if err := cs.manager.metrics.serve(serveCtx, cs.cfg.Metrics.Port); err != nil {
+				log.Error().Err(err).Msg("Metrics server failed")
+			}
+		}()
+	}
+
+	if cs.cfg.Gateway.Enabled {
+		gw, err := newGateway(cs, unary, stream)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to configure HTTP gateway")
+			s.Stop()
+			return err
+		}
+
+		go func() {
+			if err := gw.serve(serveCtx, cs.cfg.Gateway.Port); err != nil {
+				log.Error().Err(err).Msg("HTTP gateway failed")
+			}
+		}()
+	}
 
 	// Block until a termination signal is received
 	terminationChan := make(chan os.Signal, 1)
@@ -54,79 +152,193 @@ func (cs *GoProcServer) StartServer(ctx context.Context, port uint) error {
 	sig := <-terminationChan
 	log.Info().Msgf("Termination signal (%v) received. Shutting down server...", sig)
 
-	s.GracefulStop()
+	if healthServer != nil {
+		healthServer.Shutdown()
+	}
+
+	stopServer(s, cs.manager)
 	return nil
 }
 
+// registerServices registers the GoProc service on s, along with the health
+// and reflection services when they are enabled. It returns the health server,
+// or nil.
+func (cs *GoProcServer) registerServices(s *grpc.Server) *health.Server {
+	proto.RegisterGoProcServer(s, cs)
+
+	var healthServer *health.Server
+	if cs.cfg.HealthCheck {
+		healthServer = health.NewServer()
+		healthServer.SetServingStatus(proto.GoProc_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+		healthpb.RegisterHealthServer(s, healthServer)
+	}
+
+	if cs.cfg.Reflection {
+		reflection.Register(s)
+	}
+
+	return healthServer
+}
+
+// stopServer shuts m down, which ends the calls waiting on processes, events
+// or files with ErrServerShuttingDown, then stops s, cancelling whatever is
+// still in flight. GracefulStop is not used: it waits for every handler while
+// holding the lock Stop needs, so it can't be cut short.
+func stopServer(s *grpc.Server, m *Manager) {
+	m.Shutdown()
+	s.Stop()
+}
+
+// interceptors returns the interceptor chains shared by the gRPC listener and
+// the gateway, and a func closing the audit log.
+func (cs *GoProcServer) interceptors() ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, func() error, error) {
+	closeAudit := func() error { return nil }
+
+	// The legacy errors interceptor is outermost, so that metrics and audit
+	// see the real status code of calls answered with ok=false.
+	unary := []grpc.UnaryServerInterceptor{legacyErrorsInterceptor, cs.manager.metrics.unaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{cs.manager.metrics.streamInterceptor}
+
+	// Outside the authentication checks, so that the calls they reject are
+	// audited too.
+	if cs.cfg.Audit.Enabled {
+		audit, err := newAuditor(cs.cfg.Audit)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to open audit log")
+			return nil, nil, nil, err
+		}
+		closeAudit = audit.Close
+
+		unary = append(unary, audit.unaryInterceptor)
+		stream = append(stream, audit.streamInterceptor)
+	}
+
+	if cs.cfg.UnixSocket.Path != "" {
+		pc := &peerCredChecker{allowedUIDs: cs.cfg.UnixSocket.AllowedUIDs}
+		unary = append(unary, pc.unaryInterceptor)
+		stream = append(stream, pc.streamInterceptor)
+	}
+
+	if cs.cfg.Auth.Enabled {
+		auth, err := newAuthenticator(cs.cfg.Auth)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load auth tokens")
+			closeAudit()
+			return nil, nil, nil, err
+		}
+
+		unary = append(unary, auth.unaryInterceptor)
+		stream = append(stream, auth.streamInterceptor)
+	}
+
+	return unary, stream, closeAudit, nil
+}
+
 func (cs *GoProcServer) Exec(ctx context.Context, req *proto.ExecProcessRequest) (*proto.ExecProcessResponse, error) {
-	proc, err := NewProcess(ctx)
+	res, err := cs.manager.Exec(ctx, req)
 	if err != nil {
+		pid := int32(startedPID(res))
 		return &proto.ExecProcessResponse{
 			Ok:       false,
+			Pid:      pid,
 			ErrorMsg: err.Error(),
-		}, nil
+		}, statusError(err, pid)
+	}
+
+	return execResponse(res), nil
+}
+
+// ExecUpload runs Exec with stdin uploaded by the client. The response is sent
+// once the client has closed its side of the stream and, if the request
+// waits, the process has exited.
+func (cs *GoProcServer) ExecUpload(stream proto.GoProc_ExecUploadServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
 	}
 
-	wait := false
-	if req.Wait != nil {
-		wait = *req.Wait
+	if first.Exec == nil {
+		return statusError(fmt.Errorf("%w: the first message has no exec request", ErrNoCommand), 0)
 	}
 
-	pid, err := proc.Exec(req.Args, req.Cwd, req.Env, wait)
+	upload := &uploadReader{recv: stream.Recv, buf: first.Stdin}
+	res, err := cs.manager.ExecUpload(stream.Context(), first.Exec, upload)
 	if err != nil {
-		return &proto.ExecProcessResponse{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, nil
+		return streamError(err, int32(startedPID(res)))
 	}
 
-	cs.processMap.Store(pid, proc)
+	return stream.SendAndClose(execResponse(res))
+}
 
-	return &proto.ExecProcessResponse{
+// startedPID returns the pid of the process an exec that failed had started,
+// or 0.
+func startedPID(res *ExecResult) int {
+	if res == nil {
+		return 0
+	}
+	return res.PID
+}
+
+func execResponse(res *ExecResult) *proto.ExecProcessResponse {
+	resp := &proto.ExecProcessResponse{
 		Ok:       true,
-		Pid:      int32(pid),
+		Pid:      int32(res.PID),
 		ErrorMsg: "",
-	}, nil
+	}
+
+	if res.Exited {
+		exitCode := int32(res.ExitCode)
+		resp.ExitCode = &exitCode
+		resp.StageExitCodes = int32s(res.StageExitCodes)
+	}
+
+	return resp
 }
 
-func (cs *GoProcServer) Wait(ctx context.Context, req *proto.WaitProcessRequest) (*proto.WaitProcessResponse, error) {
-	proc, err := cs.getProcess(req.Pid)
-	if err != nil {
-		return &proto.WaitProcessResponse{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, nil
+// uploadReader reads the stdin chunks of an ExecUpload stream, returning
+// io.EOF once the client has closed its side.
+type uploadReader struct {
+	recv func() (*proto.ExecUploadRequest, error)
+	buf  []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.Stdin
 	}
 
-	exitCode, err := proc.Wait()
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (cs *GoProcServer) Wait(ctx context.Context, req *proto.WaitProcessRequest) (*proto.WaitProcessResponse, error) {
+	res, err := cs.manager.Wait(ctx, int(req.Pid))
 	if err != nil {
 		return &proto.WaitProcessResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
-		}, nil
+		}, statusError(err, req.Pid)
 	}
 
 	return &proto.WaitProcessResponse{
-		Ok:       true,
-		ExitCode: int32(exitCode),
+		Ok:             true,
+		ExitCode:       int32(res.ExitCode),
+		StageExitCodes: int32s(res.StageExitCodes),
 	}, nil
 }
 
 func (cs *GoProcServer) Kill(ctx context.Context, req *proto.KillProcessRequest) (*proto.KillProcessResponse, error) {
-	proc, err := cs.getProcess(req.Pid)
+	err := cs.manager.Kill(ctx, int(req.Pid))
 	if err != nil {
 		return &proto.KillProcessResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
-		}, nil
-	}
-
-	err = proc.Kill()
-	if err != nil {
-		return &proto.KillProcessResponse{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, nil
+		}, statusError(err, req.Pid)
 	}
 
 	return &proto.KillProcessResponse{
@@ -136,20 +348,12 @@ func (cs *GoProcServer) Kill(ctx context.Context, req *proto.KillProcessRequest)
 }
 
 func (cs *GoProcServer) Signal(ctx context.Context, req *proto.SignalProcessRequest) (*proto.SignalProcessResponse, error) {
-	proc, err := cs.getProcess(req.Pid)
-	if err != nil {
-		return &proto.SignalProcessResponse{
-			Ok:       false,
-			ErrorMsg: err.Error(),
-		}, nil
-	}
-
-	err = proc.Signal(syscall.Signal(req.Signal))
+	err := cs.manager.Signal(ctx, int(req.Pid), syscall.Signal(req.Signal))
 	if err != nil {
 		return &proto.SignalProcessResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
-		}, nil
+		}, statusError(err, req.Pid)
 	}
 
 	return &proto.SignalProcessResponse{
@@ -159,107 +363,223 @@ func (cs *GoProcServer) Signal(ctx context.Context, req *proto.SignalProcessRequ
 }
 
 func (cs *GoProcServer) Status(ctx context.Context, req *proto.StatusProcessRequest) (*proto.StatusProcessResponse, error) {
-	proc, err := cs.getProcess(req.Pid)
+	info, err := cs.manager.processInfo(int(req.Pid))
 	if err != nil {
 		return &proto.StatusProcessResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
-		}, nil
+		}, statusError(err, req.Pid)
 	}
 
 	return &proto.StatusProcessResponse{
 		Ok:       true,
 		ErrorMsg: "",
-		Process: &proto.ProcessInfo{
-			Pid:      int32(proc.pid),
-			Cmd:      proc.cmd.String(),
-			Cwd:      proc.cmd.Dir,
-			Env:      proc.cmd.Env,
-			Running:  proc.Running(),
-			ExitCode: int32(proc.ExitCode()),
-		},
+		Process:  info,
 	}, nil
 }
 
 func (cs *GoProcServer) Stdout(ctx context.Context, req *proto.StdoutProcessRequest) (*proto.StdoutProcessResponse, error) {
-	proc, err := cs.getProcess(req.Pid)
+	stdout, err := cs.manager.Stdout(ctx, int(req.Pid))
 	if err != nil {
 		return &proto.StdoutProcessResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
 			Stdout:   "",
-		}, nil
+		}, statusError(err, req.Pid)
 	}
 
 	return &proto.StdoutProcessResponse{
 		Ok:       true,
 		ErrorMsg: "",
-		Stdout:   proc.Stdout(),
+		Stdout:   stdout,
 	}, nil
 }
 
 func (cs *GoProcServer) Stderr(ctx context.Context, req *proto.StderrProcessRequest) (*proto.StderrProcessResponse, error) {
-	proc, err := cs.getProcess(req.Pid)
+	stderr, err := cs.manager.Stderr(ctx, int(req.Pid))
 	if err != nil {
 		return &proto.StderrProcessResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
 			Stderr:   "",
-		}, nil
+		}, statusError(err, req.Pid)
 	}
 
 	return &proto.StderrProcessResponse{
 		Ok:       true,
 		ErrorMsg: "",
-		Stderr:   proc.Stderr(),
+		Stderr:   stderr,
 	}, nil
 }
 
 func (cs *GoProcServer) ListProcesses(ctx context.Context, req *proto.ListProcessesRequest) (*proto.ListProcessesResponse, error) {
-	processes, err := cs.listProcesses()
+	processes, next, err := cs.manager.listProcesses(req)
 	if err != nil {
 		return &proto.ListProcessesResponse{
 			Ok:       false,
 			ErrorMsg: err.Error(),
-		}, nil
+		}, statusError(err, 0)
 	}
 
 	return &proto.ListProcessesResponse{
-		Ok:        true,
-		ErrorMsg:  "",
-		Processes: processes,
+		Ok:            true,
+		ErrorMsg:      "",
+		Processes:     processes,
+		NextPageToken: next,
 	}, nil
+}
+
+func (cs *GoProcServer) WatchEvents(req *proto.WatchEventsRequest, stream proto.GoProc_WatchEventsServer) error {
+	err := cs.manager.WatchEvents(stream.Context(), req, stream.Send)
+	return streamError(err, 0)
+}
+
+func (cs *GoProcServer) StreamOutput(req *proto.StreamOutputRequest, stream proto.GoProc_StreamOutputServer) error {
+	err := cs.manager.StreamOutput(stream.Context(), int(req.Pid), req.Follow, stream.Send)
+	return streamError(err, req.Pid)
+}
+
+// Attach bridges a caller to a process. The first request names the process
+// and may already carry input.
+func (cs *GoProcServer) Attach(stream proto.GoProc_AttachServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	in := &attachStream{recv: stream.Recv, pending: first}
+	err = cs.manager.Attach(stream.Context(), int(first.Pid), in.next, stream.Send)
+	return streamError(err, first.Pid)
+}
+
+// attachStream reads an Attach stream for Manager.Attach, which needs reads it
+// can give up on. A stream read can't be cancelled, so each one runs in its
+// own goroutine: next returns once ctx is done, and no read is started after
+// that. The read still in progress ends with the call.
+type attachStream struct {
+	recv    func() (*proto.AttachRequest, error)
+	pending *proto.AttachRequest
+	reading chan attachRead
+}
+
+type attachRead struct {
+	req *proto.AttachRequest
+	err error
+}
 
+func (in *attachStream) next(ctx context.Context) (*proto.AttachRequest, error) {
+	if req := in.pending; req != nil {
+		in.pending = nil
+		return req, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if in.reading == nil {
+		reading := make(chan attachRead, 1)
+		go func() {
+			req, err := in.recv()
+			reading <- attachRead{req, err}
+		}()
+		in.reading = reading
+	}
+
+	select {
+	case read := <-in.reading:
+		in.reading = nil
+		return read.req, read.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (cs *GoProcServer) KillMatching(ctx context.Context, req *proto.KillMatchingRequest) (*proto.SignalMatchingResponse, error) {
+	results, err := cs.manager.KillMatching(ctx, req.LabelSelector)
+	return signalMatchingResponse(results, err)
+}
+
+func (cs *GoProcServer) SignalMatching(ctx context.Context, req *proto.SignalMatchingRequest) (*proto.SignalMatchingResponse, error) {
+	results, err := cs.manager.SignalMatching(ctx, req.LabelSelector, syscall.Signal(req.Signal))
+	return signalMatchingResponse(results, err)
+}
+
+func signalMatchingResponse(results []*SignalResult, err error) (*proto.SignalMatchingResponse, error) {
+	if err != nil {
+		return &proto.SignalMatchingResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, statusError(err, 0)
+	}
+
+	resp := &proto.SignalMatchingResponse{Ok: true}
+	for _, res := range results {
+		result := &proto.SignalResult{Pid: int32(res.PID), Ok: res.Err == nil}
+		if res.Err != nil {
+			var code codes.Code
+			result.ErrorMsg, code, result.Reason = errorDetail(res.Err, int32(res.PID))
+			result.Code = int32(code)
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
 }
 
-func (cs *GoProcServer) listProcesses() ([]*proto.ProcessInfo, error) {
-	processes := make([]*proto.ProcessInfo, 0)
+func (cs *GoProcServer) WaitAll(ctx context.Context, req *proto.WaitAllRequest) (*proto.WaitAllResponse, error) {
+	results, err := cs.manager.WaitAll(ctx, req.LabelSelector)
+	if err != nil {
+		return &proto.WaitAllResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, streamError(err, 0)
+	}
 
-	cs.processMap.Range(func(key, value interface{}) bool {
-		processes = append(processes, &proto.ProcessInfo{
-			Pid:      int32(key.(int)),
-			Cmd:      value.(*Process).cmd.String(),
-			Cwd:      value.(*Process).cmd.Dir,
-			Env:      value.(*Process).cmd.Env,
-			Running:  value.(*Process).Running(),
-			ExitCode: int32(value.(*Process).ExitCode()),
+	resp := &proto.WaitAllResponse{Ok: true}
+	for _, res := range results {
+		resp.Results = append(resp.Results, &proto.ProcessExit{
+			Pid:            int32(res.PID),
+			ExitCode:       int32(res.ExitCode),
+			StageExitCodes: int32s(res.StageExitCodes),
 		})
-		return true
-	})
+	}
+
+	return resp, nil
+}
+
+// streamError converts an error ending a streaming RPC. Errors from sending
+// on the stream are returned as they are.
+func streamError(err error, pid int32) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, ErrResumeTokenExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrSubscriberTooSlow):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
 
-	return processes, nil
+	return statusError(err, pid)
 }
 
-func (cs *GoProcServer) getProcess(pid int32) (*Process, error) {
-	procIface, ok := cs.processMap.Load(int(pid))
-	if !ok {
-		return nil, errors.New("process not found")
+func int32s(values []int) []int32 {
+	if values == nil {
+		return nil
 	}
 
-	proc, ok := procIface.(*Process)
-	if !ok {
-		return nil, errors.New("process not found")
+	out := make([]int32, 0, len(values))
+	for _, v := range values {
+		out = append(out, int32(v))
 	}
 
-	return proc, nil
+	return out
 }
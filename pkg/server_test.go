@@ -0,0 +1,415 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T, cfg GoProcConfig) *GoProcServer {
+	t.Helper()
+
+	if cfg.Shutdown.Policy == "" {
+		cfg.Shutdown.Policy = ShutdownKill
+	}
+
+	cs, err := NewGoProcServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cs.manager.Shutdown)
+
+	return cs
+}
+
+// startTestServer serves cs on a loopback port and returns a client for it.
+func startTestServer(t *testing.T, cs *GoProcServer) *GoProcClient {
+	t.Helper()
+
+	unary, stream, closeInterceptors, err := cs.interceptors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { closeInterceptors() })
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	proto.RegisterGoProcServer(s, cs)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	c, err := NewGoProcClient(context.Background(), "127.0.0.1", uint(lis.Addr().(*net.TCPAddr).Port), WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Cleanup() })
+
+	return c
+}
+
+func TestClientErrors(t *testing.T) {
+	cs := newTestServer(t, GoProcConfig{Policy: PolicyConfig{Deny: []PolicyRule{{Name: "no-rm", Executables: []string{"rm"}}}}})
+	c := startTestServer(t, cs)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		err    error
+		policy bool
+	}{
+		{name: "Status", call: func() error {
+			_, err := c.Status(ctx, 999999)
+			return err
+		}, err: ErrProcessNotFound},
+		{name: "StreamOutput", call: func() error {
+			_, err := c.StreamOutput(ctx, 999999, true, nil, nil)
+			return err
+		}, err: ErrProcessNotFound},
+		{name: "Attach", call: func() error {
+			_, err := c.Attach(ctx, 999999, AttachStreams{})
+			return err
+		}, err: ErrProcessNotFound},
+		{name: "Exec", call: func() error {
+			_, err := c.Exec(ctx, []string{"rm", "-f", "x"})
+			return err
+		}, err: ErrPolicyViolation, policy: true},
+		{name: "ExecUpload", call: func() error {
+			_, err := c.ExecUpload(ctx, []string{"rm", "-f", "x"}, strings.NewReader("data"))
+			return err
+		}, err: ErrPolicyViolation, policy: true},
+		{name: "ExecUpload no command", call: func() error {
+			_, err := c.ExecUpload(ctx, nil, strings.NewReader("data"))
+			return err
+		}, err: ErrNoCommand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+
+			var remoteErr *RemoteError
+			if !errors.As(err, &remoteErr) {
+				t.Errorf("got %T, want a *RemoteError", err)
+			}
+
+			var policyErr *PolicyViolationError
+			if tt.policy && (!errors.As(err, &policyErr) || policyErr.Rule != "no-rm") {
+				t.Errorf("got %v, want a violation of rule no-rm", err)
+			}
+		})
+	}
+}
+
+func TestInterceptorsSeeLegacyErrors(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	cs := newTestServer(t, GoProcConfig{Audit: AuditConfig{Enabled: true, Path: auditPath}})
+
+	unary, _, closeInterceptors, err := cs.interceptors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeInterceptors()
+
+	chain := chainUnaryInterceptors(unary)
+	info := &grpc.UnaryServerInfo{FullMethod: "/goproc.GoProc/Kill"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return &proto.KillProcessResponse{Ok: false, ErrorMsg: ErrProcessNotFound.Error()}, statusError(ErrProcessNotFound, 7)
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		legacy  bool
+	}{
+		{name: "version 1", legacy: true},
+		{name: "version 2", version: "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.version != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(protocolVersionHeader, tt.version))
+			}
+
+			resp, err := chain(ctx, &proto.KillProcessRequest{Pid: 7}, info, handler)
+			if tt.legacy {
+				if err != nil || resp.(*proto.KillProcessResponse).Ok {
+					t.Fatalf("got %v, %v, want an ok=false response", resp, err)
+				}
+			} else if status.Code(err) != codes.NotFound {
+				t.Fatalf("got %v, want NotFound", err)
+			}
+		})
+	}
+
+	if got := rpcCount(t, cs.manager.metrics, "Kill", codes.NotFound); got != uint64(len(tests)) {
+		t.Errorf("metrics counted %d NotFound calls, want %d", got, len(tests))
+	}
+
+	records := readAuditLog(t, auditPath)
+	if len(records) != len(tests) {
+		t.Fatalf("got %d audit records, want %d", len(records), len(tests))
+	}
+	for _, rec := range records {
+		if rec.Result != auditResultError {
+			t.Errorf("got audit result %q, want %q", rec.Result, auditResultError)
+		}
+	}
+}
+
+// rpcCount returns how many calls to method the metrics recorded with code.
+func rpcCount(t *testing.T, m *serverMetrics, method string, code codes.Code) uint64 {
+	t.Helper()
+
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != metricsNamespace+"_rpc_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["method"] == method && labels["code"] == code.String() {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestStopServerCutsOffCallsInFlight(t *testing.T) {
+	cs := newTestServer(t, GoProcConfig{Shutdown: ShutdownConfig{Policy: ShutdownLeave}})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	proto.RegisterGoProcServer(s, cs)
+	go s.Serve(lis)
+
+	c, err := NewGoProcClient(context.Background(), "127.0.0.1", uint(lis.Addr().(*net.TCPAddr).Port), WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Cleanup()
+
+	// Waits for the process, which the leave policy lets outlive the server.
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := c.Exec(context.Background(), []string{"sleep", "60"}, WithWait())
+		waitErr <- err
+	}()
+
+	var pid int
+	deadline := time.Now().Add(5 * time.Second)
+	for pid == 0 {
+		infos, _, err := cs.manager.listProcesses(&proto.ListProcessesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(infos) > 0 {
+			pid = int(infos[0].Pid)
+		} else if time.Now().After(deadline) {
+			t.Fatal("exec not in flight")
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	defer syscall.Kill(pid, syscall.SIGKILL)
+
+	start := time.Now()
+	stopServer(s, cs.manager)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("stopServer took %v", elapsed)
+	}
+
+	select {
+	case err := <-waitErr:
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("got %v, want Unavailable", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("exec still in flight after stopServer")
+	}
+}
+
+func TestAttachStreamStopsReading(t *testing.T) {
+	first := &proto.AttachRequest{Pid: 1}
+	reads := make(chan chan *proto.AttachRequest, 10)
+	in := &attachStream{pending: first, recv: func() (*proto.AttachRequest, error) {
+		read := make(chan *proto.AttachRequest)
+		reads <- read
+		return <-read, nil
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if req, err := in.next(ctx); err != nil || req != first {
+		t.Fatalf("got %v, %v, want the first request", req, err)
+	}
+
+	// A read given up on is picked up by the next call rather than repeated.
+	nextErr := make(chan error, 1)
+	go func() {
+		_, err := in.next(ctx)
+		nextErr <- err
+	}()
+	read := <-reads
+	cancel()
+	if err := <-nextErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := in.next(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	want := &proto.AttachRequest{Stdin: []byte("x")}
+	read <- want
+	if req, err := in.next(context.Background()); err != nil || req != want {
+		t.Fatalf("got %v, %v, want the read in progress", req, err)
+	}
+
+	if n := len(reads); n != 0 {
+		t.Errorf("started %d more reads, want none", n)
+	}
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	tokens := `tokens:
+  - name: ci
+    token: ci-token
+    methods: ["*"]
+`
+	if err := os.WriteFile(tokenFile, []byte(tokens), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		healthCode codes.Code
+	}{
+		{name: "enabled", enabled: true, healthCode: codes.OK},
+		{name: "disabled", healthCode: codes.Unimplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := newTestServer(t, GoProcConfig{
+				HealthCheck: tt.enabled,
+				Reflection:  tt.enabled,
+				Auth:        AuthConfig{Enabled: true, TokenFile: tokenFile},
+			})
+
+			unary, stream, closeInterceptors, err := cs.interceptors()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer closeInterceptors()
+
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+			healthServer := cs.registerServices(s)
+			go s.Serve(lis)
+			defer s.Stop()
+
+			conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			ctx := context.Background()
+
+			// No token: health checks skip authentication.
+			health := healthpb.NewHealthClient(conn)
+			check := &healthpb.HealthCheckRequest{Service: proto.GoProc_ServiceDesc.ServiceName}
+			resp, err := health.Check(ctx, check)
+			if status.Code(err) != tt.healthCode {
+				t.Fatalf("got %v, want %v", err, tt.healthCode)
+			}
+			if !tt.enabled {
+				if healthServer != nil {
+					t.Error("got a health server with health checks disabled")
+				}
+			} else {
+				if resp.Status != healthpb.HealthCheckResponse_SERVING {
+					t.Errorf("got %v, want SERVING", resp.Status)
+				}
+
+				healthServer.Shutdown()
+				if resp, err := health.Check(ctx, check); err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+					t.Errorf("got %v, %v after shutdown, want NOT_SERVING", resp, err)
+				}
+			}
+
+			authed := metadata.AppendToOutgoingContext(ctx, authorizationHeader, bearerPrefix+"ci-token")
+			refl, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(authed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = refl.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			listed, err := refl.Recv()
+			if !tt.enabled {
+				if status.Code(err) != codes.Unimplemented {
+					t.Errorf("got %v, want Unimplemented", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var services []string
+			for _, svc := range listed.GetListServicesResponse().GetService() {
+				services = append(services, svc.Name)
+			}
+			for _, want := range []string{proto.GoProc_ServiceDesc.ServiceName, "grpc.health.v1.Health"} {
+				if !slices.Contains(services, want) {
+					t.Errorf("reflection lists %v, want %s", services, want)
+				}
+			}
+		})
+	}
+}
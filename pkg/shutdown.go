@@ -0,0 +1,85 @@
+package goproc
+
+import (
+	"errors"
+	"syscall"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Shutdown policies for the processes still running when the server stops.
+const (
+	ShutdownLeave     = "leave"
+	ShutdownTerminate = "terminate"
+	ShutdownKill      = "kill"
+)
+
+// killWaitTimeout bounds how long the server waits for a killed process to be
+// reaped before moving on to the next one.
+const killWaitTimeout = 5 * time.Second
+
+func validateShutdownConfig(cfg ShutdownConfig) error {
+	switch cfg.Policy {
+	case ShutdownLeave, ShutdownTerminate, ShutdownKill:
+		return nil
+	}
+
+	return ErrInvalidShutdownPolicy
+}
+
+// stopProcesses applies the shutdown policy to the running processes, most
+// recently started first so that processes are stopped before the ones they
+// were started after.
+func (m *Manager) stopProcesses() {
+	procs := m.runningProcesses()
+	if len(procs) == 0 || m.cfg.Shutdown.Policy == ShutdownLeave {
+		return
+	}
+
+	log.Info().Str("policy", m.cfg.Shutdown.Policy).Int("processes", len(procs)).Msg("Stopping processes")
+
+	if m.cfg.Shutdown.Policy == ShutdownKill {
+		m.killProcesses(procs)
+		return
+	}
+
+	deadline := time.After(time.Duration(m.cfg.Shutdown.GracePeriodS) * time.Second)
+	for i, proc := range procs {
+		m.signalOnShutdown(proc, syscall.SIGTERM)
+
+		select {
+		case <-proc.done:
+		case <-deadline:
+			log.Warn().Int("processes", len(procs)-i).Msg("Shutdown grace period expired, killing remaining processes")
+			m.killProcesses(procs[i:])
+			return
+		}
+	}
+}
+
+func (m *Manager) killProcesses(procs []*Process) {
+	for _, proc := range procs {
+		m.signalOnShutdown(proc, syscall.SIGKILL)
+
+		select {
+		case <-proc.done:
+		case <-time.After(killWaitTimeout):
+			log.Warn().Int("pid", proc.pid).Msg("Process did not exit after SIGKILL")
+		}
+	}
+}
+
+func (m *Manager) signalOnShutdown(proc *Process, sig syscall.Signal) {
+	err := proc.Signal(sig)
+	if errors.Is(err, ErrProcessExited) {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("pid", proc.pid).Msgf("Failed to send %v", sig)
+		return
+	}
+
+	m.events.publish(proto.ProcessEventType_PROCESS_EVENT_SIGNALED, processInfo(proc), int32(sig))
+}
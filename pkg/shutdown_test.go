@@ -0,0 +1,73 @@
+package goproc
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+)
+
+// ignoresTerm is a command that keeps running after SIGTERM.
+var ignoresTerm = []string{"sh", "-c", `trap "" TERM; exec sleep 60`}
+
+func TestShutdownPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		shutdown ShutdownConfig
+		args     []string
+		// exitCode is how the process ends, or 0 if it is left running.
+		exitCode int
+		minDelay time.Duration
+	}{
+		{name: "leave", shutdown: ShutdownConfig{Policy: ShutdownLeave}, args: []string{"sleep", "60"}},
+		{name: "kill", shutdown: ShutdownConfig{Policy: ShutdownKill}, args: ignoresTerm, exitCode: 128 + int(syscall.SIGKILL)},
+		{name: "terminate", shutdown: ShutdownConfig{Policy: ShutdownTerminate, GracePeriodS: 5}, args: []string{"sleep", "60"}, exitCode: 128 + int(syscall.SIGTERM)},
+		{name: "terminate escalates", shutdown: ShutdownConfig{Policy: ShutdownTerminate, GracePeriodS: 1}, args: ignoresTerm, exitCode: 128 + int(syscall.SIGKILL), minDelay: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t, GoProcConfig{Shutdown: tt.shutdown})
+			res, err := m.Exec(context.Background(), &proto.ExecProcessRequest{Args: tt.args})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer syscall.Kill(res.PID, syscall.SIGKILL)
+
+			procs := m.runningProcesses()
+			if len(procs) != 1 {
+				t.Fatalf("got %d running processes, want 1", len(procs))
+			}
+			proc := procs[0]
+
+			// Give sh time to ignore SIGTERM before the server sends it.
+			time.Sleep(100 * time.Millisecond)
+
+			start := time.Now()
+			m.Shutdown()
+			elapsed := time.Since(start)
+
+			if tt.exitCode == 0 {
+				if !proc.Running() {
+					t.Fatalf("process exited with %d, want it left running", proc.ExitCode())
+				}
+				if err := syscall.Kill(res.PID, 0); err != nil {
+					t.Fatalf("process %d is gone: %v", res.PID, err)
+				}
+				return
+			}
+
+			if proc.Running() {
+				t.Fatal("process still running after shutdown")
+			}
+			if code := proc.ExitCode(); code != tt.exitCode {
+				t.Errorf("got exit code %d, want %d", code, tt.exitCode)
+			}
+			if elapsed < tt.minDelay {
+				t.Errorf("shutdown took %v, want at least the %v grace period", elapsed, tt.minDelay)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os/exec"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	// ProtocolVersion is the GoProc protocol version implemented by this
+	// package. From version 2 on, failed calls return a gRPC status error
+	// carrying an ErrorInfo detail instead of ok=false and error_msg.
+	ProtocolVersion       = 2
+	protocolVersionHeader = "goproc-protocol-version"
+	errorDomain           = "goproc"
+)
+
+// Reasons set on the ErrorInfo detail of status errors returned by the server.
+const (
+	ReasonProcessNotFound    = "PROCESS_NOT_FOUND"
+	ReasonProcessExited      = "PROCESS_EXITED"
+	ReasonNoCommand          = "NO_COMMAND"
+	ReasonPolicyViolation    = "POLICY_VIOLATION"
+	ReasonExecutableNotFound = "EXECUTABLE_NOT_FOUND"
+	ReasonPermissionDenied   = "PERMISSION_DENIED"
+	ReasonStdinNotOpen       = "STDIN_NOT_OPEN"
+	ReasonNoTerminal         = "NO_TERMINAL"
+	ReasonIdempotencyKeyUsed = "IDEMPOTENCY_KEY_REUSED"
+	ReasonInvalidPipeline    = "INVALID_PIPELINE"
+	ReasonInvalidOutput      = "INVALID_OUTPUT_TARGET"
+	ReasonInvalidStdin       = "INVALID_STDIN"
+	ReasonInvalidEnv         = "INVALID_ENV"
+	ReasonInvalidLabels      = "INVALID_LABELS"
+	ReasonInvalidSelector    = "INVALID_SELECTOR"
+	ReasonInvalidPageToken   = "INVALID_PAGE_TOKEN"
+	ReasonShuttingDown       = "SERVER_SHUTTING_DOWN"
+	ReasonCanceled           = "CANCELLED"
+	ReasonDeadlineExceeded   = "DEADLINE_EXCEEDED"
+	ReasonInternal           = "INTERNAL"
+)
+
+// reasonErrors maps ErrorInfo reasons back to the errors the client returns.
+var reasonErrors = map[string]error{
+	ReasonProcessNotFound:    ErrProcessNotFound,
+	ReasonProcessExited:      ErrProcessExited,
+	ReasonNoCommand:          ErrNoCommand,
+	ReasonExecutableNotFound: ErrExecutableNotFound,
+	ReasonStdinNotOpen:       ErrStdinNotOpen,
+	ReasonNoTerminal:         ErrNoTerminal,
+	ReasonIdempotencyKeyUsed: ErrIdempotencyKeyReused,
+	ReasonInvalidPipeline:    ErrInvalidPipeline,
+	ReasonInvalidOutput:      ErrInvalidOutputTarget,
+	ReasonInvalidStdin:       ErrInvalidStdin,
+	ReasonInvalidEnv:         ErrInvalidEnv,
+	ReasonInvalidLabels:      ErrInvalidLabels,
+	ReasonInvalidSelector:    ErrInvalidSelector,
+	ReasonInvalidPageToken:   ErrInvalidPageToken,
+	ReasonShuttingDown:       ErrServerShuttingDown,
+	ReasonCanceled:           context.Canceled,
+	ReasonDeadlineExceeded:   context.DeadlineExceeded,
+}
+
+// statusError converts an error from the process layer into a gRPC status
+// error with an ErrorInfo detail. pid is added to the detail metadata when
+// non-zero.
+func statusError(err error, pid int32) error {
+	code, reason := codes.Internal, ReasonInternal
+	md := map[string]string{}
+
+	var policyErr *PolicyViolationError
+	var execErr *exec.Error
+	switch {
+	case errors.Is(err, ErrProcessNotFound):
+		code, reason = codes.NotFound, ReasonProcessNotFound
+	case errors.Is(err, ErrProcessExited):
+		code, reason = codes.FailedPrecondition, ReasonProcessExited
+	case errors.Is(err, ErrNoCommand):
+		code, reason = codes.InvalidArgument, ReasonNoCommand
+	case errors.Is(err, ErrStdinNotOpen):
+		code, reason = codes.FailedPrecondition, ReasonStdinNotOpen
+	case errors.Is(err, ErrNoTerminal):
+		code, reason = codes.FailedPrecondition, ReasonNoTerminal
+	case errors.Is(err, ErrIdempotencyKeyReused):
+		code, reason = codes.InvalidArgument, ReasonIdempotencyKeyUsed
+	case errors.Is(err, ErrInvalidPipeline):
+		code, reason = codes.InvalidArgument, ReasonInvalidPipeline
+	case errors.Is(err, ErrInvalidOutputTarget):
+		code, reason = codes.InvalidArgument, ReasonInvalidOutput
+	case errors.Is(err, ErrInvalidStdin):
+		code, reason = codes.InvalidArgument, ReasonInvalidStdin
+	case errors.Is(err, ErrInvalidEnv):
+		code, reason = codes.InvalidArgument, ReasonInvalidEnv
+	case errors.Is(err, ErrInvalidLabels):
+		code, reason = codes.InvalidArgument, ReasonInvalidLabels
+	case errors.Is(err, ErrInvalidSelector):
+		code, reason = codes.InvalidArgument, ReasonInvalidSelector
+	case errors.Is(err, ErrInvalidPageToken):
+		code, reason = codes.InvalidArgument, ReasonInvalidPageToken
+	case errors.Is(err, ErrServerShuttingDown):
+		code, reason = codes.Unavailable, ReasonShuttingDown
+	case errors.Is(err, context.Canceled):
+		code, reason = codes.Canceled, ReasonCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code, reason = codes.DeadlineExceeded, ReasonDeadlineExceeded
+	case errors.As(err, &policyErr):
+		code, reason = codes.PermissionDenied, ReasonPolicyViolation
+		md["rule"] = policyErr.Rule
+		md["reason"] = policyErr.Reason
+	case errors.Is(err, ErrExecutableNotFound), errors.Is(err, exec.ErrNotFound), errors.As(err, &execErr):
+		code, reason = codes.NotFound, ReasonExecutableNotFound
+	case errors.Is(err, fs.ErrPermission):
+		code, reason = codes.PermissionDenied, ReasonPermissionDenied
+	}
+
+	if pid != 0 {
+		md["pid"] = strconv.Itoa(int(pid))
+	}
+
+	st := status.New(code, err.Error())
+	if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: md,
+	}); detailErr == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+// errorDetail returns the message, gRPC code and ErrorInfo reason that
+// statusError reports err with, for responses carrying a result per process.
+func errorDetail(err error, pid int32) (string, codes.Code, string) {
+	st := status.Convert(statusError(err, pid))
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return st.Message(), st.Code(), info.Reason
+		}
+	}
+
+	return st.Message(), st.Code(), ReasonInternal
+}
+
+// protocolVersion returns the protocol version announced by the caller, or 1
+// for callers that predate the header.
+func protocolVersion(ctx context.Context) int {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(protocolVersionHeader)
+	if len(values) == 0 {
+		return 1
+	}
+
+	version, err := strconv.Atoi(values[0])
+	if err != nil {
+		return 1
+	}
+
+	return version
+}
+
+// legacyErrorsInterceptor keeps version 1 callers working: when a handler fails
+// with a status error but also returned a response carrying ok=false and
+// error_msg, that response is sent instead of the error.
+func legacyErrorsInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	if protocolVersion(ctx) < ProtocolVersion {
+		if m, ok := resp.(protobuf.Message); ok && m.ProtoReflect().IsValid() {
+			return resp, nil
+		}
+	}
+
+	return nil, err
+}
+
+// RemoteError is returned by GoProcClient when the server reports a failure.
+// It unwraps to the matching package error, such as ErrProcessNotFound or a
+// *PolicyViolationError, and still exposes the gRPC status.
+type RemoteError struct {
+	err    error
+	status *status.Status
+}
+
+func (e *RemoteError) Error() string {
+	return e.status.Message()
+}
+
+func (e *RemoteError) Unwrap() error {
+	return e.err
+}
+
+func (e *RemoteError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// PID returns the pid the server reported the error for, or 0.
+func (e *RemoteError) PID() int {
+	for _, detail := range e.status.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == errorDomain {
+			pid, _ := strconv.Atoi(info.Metadata["pid"])
+			return pid
+		}
+	}
+
+	return 0
+}
+
+// clientError converts an error returned by a gRPC call into a *RemoteError
+// when it carries a GoProc ErrorInfo detail. Other errors are returned as is.
+func clientError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != errorDomain {
+			continue
+		}
+
+		if info.Reason == ReasonPolicyViolation {
+			return &RemoteError{
+				err:    &PolicyViolationError{Rule: info.Metadata["rule"], Reason: info.Metadata["reason"]},
+				status: st,
+			}
+		}
+
+		if reasonErr, ok := reasonErrors[info.Reason]; ok {
+			return &RemoteError{err: reasonErr, status: st}
+		}
+	}
+
+	return err
+}
+
+// resultError rebuilds the error of a per-process result reported with
+// errorDetail, the way clientError does for a failed call.
+func resultError(msg string, code codes.Code, reason string) error {
+	st := status.New(code, msg)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+
+	return clientError(st.Err())
+}
+
+// legacyError converts the error_msg of a version 1 response into an error,
+// recognising the messages of the package's own errors.
+func legacyError(msg string) error {
+	for _, err := range []error{ErrProcessNotFound, ErrProcessExited, ErrNoCommand} {
+		if msg == err.Error() {
+			return err
+		}
+	}
+
+	return errors.New(msg)
+}
+
+// withProtocolVersion announces ProtocolVersion on every outgoing call.
+func withProtocolVersion(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, protocolVersionHeader, strconv.Itoa(ProtocolVersion))
+	return clientError(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+func withProtocolVersionStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, protocolVersionHeader, strconv.Itoa(ProtocolVersion))
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	return &errorClientStream{ClientStream: stream}, nil
+}
+
+// errorClientStream converts the errors a stream ends with, which surface from
+// RecvMsg and SendMsg rather than from creating the stream.
+type errorClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *errorClientStream) RecvMsg(m any) error {
+	return clientError(s.ClientStream.RecvMsg(m))
+}
+
+func (s *errorClientStream) SendMsg(m any) error {
+	return clientError(s.ClientStream.SendMsg(m))
+}
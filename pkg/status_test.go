@@ -0,0 +1,210 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	notExist := &fs.PathError{Op: "open", Path: "/missing", Err: syscall.ENOENT}
+
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+		is     error
+	}{
+		{name: "not found", err: ErrProcessNotFound, code: codes.NotFound, reason: ReasonProcessNotFound, is: ErrProcessNotFound},
+		{name: "exited", err: ErrProcessExited, code: codes.FailedPrecondition, reason: ReasonProcessExited, is: ErrProcessExited},
+		{name: "no command", err: ErrNoCommand, code: codes.InvalidArgument, reason: ReasonNoCommand, is: ErrNoCommand},
+		{name: "stdin not open", err: ErrStdinNotOpen, code: codes.FailedPrecondition, reason: ReasonStdinNotOpen, is: ErrStdinNotOpen},
+		{name: "no terminal", err: ErrNoTerminal, code: codes.FailedPrecondition, reason: ReasonNoTerminal, is: ErrNoTerminal},
+		{name: "key reused", err: ErrIdempotencyKeyReused, code: codes.InvalidArgument, reason: ReasonIdempotencyKeyUsed, is: ErrIdempotencyKeyReused},
+		{name: "pipeline", err: fmt.Errorf("%w: stage 1 has no command", ErrInvalidPipeline), code: codes.InvalidArgument, reason: ReasonInvalidPipeline, is: ErrInvalidPipeline},
+		{name: "missing stdin file", err: fmt.Errorf("%w: %w", ErrInvalidStdin, notExist), code: codes.InvalidArgument, reason: ReasonInvalidStdin, is: ErrInvalidStdin},
+		{name: "missing env file", err: fmt.Errorf("%w: env file for A: %w", ErrInvalidEnv, notExist), code: codes.InvalidArgument, reason: ReasonInvalidEnv, is: ErrInvalidEnv},
+		{name: "missing output dir", err: fmt.Errorf("%w: %w", ErrInvalidOutputTarget, notExist), code: codes.InvalidArgument, reason: ReasonInvalidOutput, is: ErrInvalidOutputTarget},
+		{name: "labels", err: ErrInvalidLabels, code: codes.InvalidArgument, reason: ReasonInvalidLabels, is: ErrInvalidLabels},
+		{name: "selector", err: ErrInvalidSelector, code: codes.InvalidArgument, reason: ReasonInvalidSelector, is: ErrInvalidSelector},
+		{name: "page token", err: ErrInvalidPageToken, code: codes.InvalidArgument, reason: ReasonInvalidPageToken, is: ErrInvalidPageToken},
+		{name: "shutting down", err: ErrServerShuttingDown, code: codes.Unavailable, reason: ReasonShuttingDown, is: ErrServerShuttingDown},
+		{name: "canceled", err: context.Canceled, code: codes.Canceled, reason: ReasonCanceled, is: context.Canceled},
+		{name: "deadline", err: fmt.Errorf("wait: %w", context.DeadlineExceeded), code: codes.DeadlineExceeded, reason: ReasonDeadlineExceeded, is: context.DeadlineExceeded},
+		{name: "executable in PATH", err: &exec.Error{Name: "nope", Err: exec.ErrNotFound}, code: codes.NotFound, reason: ReasonExecutableNotFound, is: ErrExecutableNotFound},
+		{name: "executable by path", err: startError(&fs.PathError{Op: "fork/exec", Path: "/nope", Err: syscall.ENOENT}), code: codes.NotFound, reason: ReasonExecutableNotFound, is: ErrExecutableNotFound},
+		{name: "missing cwd", err: startError(&fs.PathError{Op: "chdir", Path: "/nope", Err: syscall.ENOENT}), code: codes.Internal, reason: ReasonInternal},
+		{name: "other missing file", err: notExist, code: codes.Internal, reason: ReasonInternal},
+		{name: "permission", err: &fs.PathError{Op: "fork/exec", Path: "/etc/passwd", Err: syscall.EACCES}, code: codes.PermissionDenied, reason: ReasonPermissionDenied},
+		{name: "policy", err: &PolicyViolationError{Rule: "no-rm", Reason: "executable /bin/rm"}, code: codes.PermissionDenied, reason: ReasonPolicyViolation, is: ErrPolicyViolation},
+		{name: "unknown", err: errors.New("boom"), code: codes.Internal, reason: ReasonInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := statusError(tt.err, 42)
+			st := status.Convert(err)
+			if st.Code() != tt.code {
+				t.Errorf("got code %v, want %v", st.Code(), tt.code)
+			}
+			if st.Message() != tt.err.Error() {
+				t.Errorf("got message %q, want %q", st.Message(), tt.err.Error())
+			}
+
+			info := errorInfo(t, st)
+			if info.Reason != tt.reason || info.Domain != errorDomain {
+				t.Errorf("got reason %s/%s, want %s/%s", info.Domain, info.Reason, errorDomain, tt.reason)
+			}
+			if info.Metadata["pid"] != "42" {
+				t.Errorf("got pid %q, want 42", info.Metadata["pid"])
+			}
+
+			clientErr := clientError(err)
+			if tt.is != nil && !errors.Is(clientErr, tt.is) {
+				t.Errorf("client error %v does not match %v", clientErr, tt.is)
+			}
+			if status.Code(clientErr) != tt.code {
+				t.Errorf("client error has code %v, want %v", status.Code(clientErr), tt.code)
+			}
+		})
+	}
+}
+
+func TestStatusErrorPolicyMetadata(t *testing.T) {
+	err := clientError(statusError(&PolicyViolationError{Rule: "no-rm", Reason: "executable /bin/rm"}, 0))
+
+	var policyErr *PolicyViolationError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("got %v, want a *PolicyViolationError", err)
+	}
+	if policyErr.Rule != "no-rm" || policyErr.Reason != "executable /bin/rm" {
+		t.Errorf("got %+v", policyErr)
+	}
+}
+
+func TestExecFileErrors(t *testing.T) {
+	m := newTestManager(t, GoProcConfig{})
+	missing := t.TempDir() + "/missing"
+
+	tests := []struct {
+		name   string
+		req    *proto.ExecProcessRequest
+		reason string
+	}{
+		{name: "stdin file", req: &proto.ExecProcessRequest{Args: []string{"cat"}, StdinFile: missing}, reason: ReasonInvalidStdin},
+		{name: "pipeline stdin file", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{
+			Stages:    []*proto.PipelineStage{{Args: []string{"cat"}}},
+			StdinFile: missing,
+		}}, reason: ReasonInvalidStdin},
+		{name: "env file", req: &proto.ExecProcessRequest{Args: []string{"true"}, EnvFiles: map[string]string{"A": missing}}, reason: ReasonInvalidEnv},
+		{name: "stdout file", req: &proto.ExecProcessRequest{Args: []string{"true"}, Stdout: &proto.OutputTarget{
+			Type: proto.OutputTargetType_OUTPUT_TARGET_FILE,
+			File: &proto.Redirect{Path: missing + "/out.log"},
+		}}, reason: ReasonInvalidOutput},
+		{name: "pipeline redirect", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{
+			Stages: []*proto.PipelineStage{{Args: []string{"true"}}},
+			Stdout: &proto.Redirect{Path: missing + "/out.log"},
+		}}, reason: ReasonInvalidOutput},
+		{name: "executable by path", req: &proto.ExecProcessRequest{Args: []string{missing}}, reason: ReasonExecutableNotFound},
+		{name: "executable in PATH", req: &proto.ExecProcessRequest{Args: []string{"goproc-no-such-command"}}, reason: ReasonExecutableNotFound},
+		{name: "pipeline stage", req: &proto.ExecProcessRequest{Pipeline: &proto.Pipeline{
+			Stages: []*proto.PipelineStage{{Args: []string{"true"}}, {Args: []string{missing}}},
+		}}, reason: ReasonExecutableNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Exec(context.Background(), tt.req)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if info := errorInfo(t, status.Convert(statusError(err, 0))); info.Reason != tt.reason {
+				t.Errorf("%v: got reason %s, want %s", err, info.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func newTestManager(t *testing.T, cfg GoProcConfig) *Manager {
+	t.Helper()
+
+	if cfg.Shutdown.Policy == "" {
+		cfg.Shutdown.Policy = ShutdownKill
+	}
+
+	m, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(m.Shutdown)
+
+	return m
+}
+
+func errorInfo(t *testing.T, st *status.Status) *errdetails.ErrorInfo {
+	t.Helper()
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+
+	t.Fatalf("status %v has no ErrorInfo", st)
+	return nil
+}
+
+func TestExecFailureAfterStartReportsPID(t *testing.T) {
+	cs := newTestServer(t, GoProcConfig{Shutdown: ShutdownConfig{Policy: ShutdownLeave}})
+
+	type result struct {
+		resp *proto.ExecProcessResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		wait := true
+		resp, err := cs.Exec(context.Background(), &proto.ExecProcessRequest{Args: []string{"sleep", "60"}, Wait: &wait})
+		done <- result{resp, err}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(cs.manager.runningProcesses()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("exec not in flight")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cs.manager.Shutdown()
+
+	r := <-done
+	if !errors.Is(clientError(r.err), ErrServerShuttingDown) {
+		t.Fatalf("got %v, want %v", r.err, ErrServerShuttingDown)
+	}
+	if r.resp.Pid == 0 {
+		t.Fatal("legacy response carries no pid")
+	}
+	defer syscall.Kill(int(r.resp.Pid), syscall.SIGKILL)
+
+	if pid := errorInfo(t, status.Convert(r.err)).Metadata["pid"]; pid != strconv.Itoa(int(r.resp.Pid)) {
+		t.Errorf("got pid %q in the error, want %d", pid, r.resp.Pid)
+	}
+
+	res := startedResult(clientError(r.err))
+	if res == nil || res.PID != int(r.resp.Pid) {
+		t.Errorf("got result %+v, want pid %d", res, r.resp.Pid)
+	}
+}
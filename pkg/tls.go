@@ -0,0 +1,111 @@
+package goproc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serverCredentials builds the transport credentials for the gRPC listener from
+// the TLS section of the config. Plaintext is only returned when explicitly
+// enabled with Insecure.
+func serverCredentials(cfg TLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig, err := serverTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// serverTLSConfig builds the server TLS config, or returns nil when plaintext
+// has been explicitly enabled with Insecure.
+func serverTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" && cfg.KeyFile == "" {
+		if !cfg.Insecure {
+			return nil, ErrTLSNotConfigured
+		}
+
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	if cfg.RequireClientCert {
+		if tlsConfig.ClientCAs == nil {
+			return nil, ErrClientCANotSet
+		}
+
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// clientTLSConfig builds a client TLS config that trusts the given CA file (or
+// the system roots when empty) and presents a client certificate when both
+// certFile and keyFile are set.
+func clientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, ErrInvalidCAFile
+	}
+
+	return pool, nil
+}
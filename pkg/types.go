@@ -5,9 +5,161 @@ const (
 )
 
 type GoProcConfig struct {
-	ServerPort           uint `key:"serverPort" json:"server_port"`
-	GRPCDialTimeoutS     int  `key:"grpcDialTimeoutS" json:"grpc_dial_timeout_s"`
-	GRPCMessageSizeBytes int  `key:"grpcMessageSizeBytes" json:"grpc_message_size_bytes"`
-	DebugMode            bool `key:"debugMode" json:"debug_mode"`
-	PrettyLogs           bool `key:"prettyLogs" json:"pretty_logs"`
+	ServerPort           uint             `key:"serverPort" json:"server_port"`
+	GRPCDialTimeoutS     int              `key:"grpcDialTimeoutS" json:"grpc_dial_timeout_s"`
+	GRPCMessageSizeBytes int              `key:"grpcMessageSizeBytes" json:"grpc_message_size_bytes"`
+	DebugMode            bool             `key:"debugMode" json:"debug_mode"`
+	PrettyLogs           bool             `key:"prettyLogs" json:"pretty_logs"`
+	TLS                  TLSConfig        `key:"tls" json:"tls"`
+	Auth                 AuthConfig       `key:"auth" json:"auth"`
+	UnixSocket           UnixSocketConfig `key:"unixSocket" json:"unix_socket"`
+	Policy               PolicyConfig     `key:"policy" json:"policy"`
+	Audit                AuditConfig      `key:"audit" json:"audit"`
+	Metrics              MetricsConfig    `key:"metrics" json:"metrics"`
+	Gateway              GatewayConfig    `key:"gateway" json:"gateway"`
+	Registry             RegistryConfig   `key:"registry" json:"registry"`
+	Shutdown             ShutdownConfig   `key:"shutdown" json:"shutdown"`
+	Init                 InitConfig       `key:"init" json:"init"`
+	Redaction            RedactionConfig  `key:"redaction" json:"redaction"`
+	EventHistorySize     int              `key:"eventHistorySize" json:"event_history_size"`
+	HealthCheck          bool             `key:"healthCheck" json:"health_check"`
+	Reflection           bool             `key:"reflection" json:"reflection"`
+	IdempotencyWindowS   int              `key:"idempotencyWindowS" json:"idempotency_window_s"`
+}
+
+// TLSConfig controls transport security for the gRPC listener. When CAFile is
+// set, client certificates are verified against it; RequireClientCert turns
+// that into mutual TLS. Plaintext is only served when Insecure is set.
+type TLSConfig struct {
+	CertFile          string `key:"certFile" json:"cert_file"`
+	KeyFile           string `key:"keyFile" json:"key_file"`
+	CAFile            string `key:"caFile" json:"ca_file"`
+	RequireClientCert bool   `key:"requireClientCert" json:"require_client_cert"`
+	Insecure          bool   `key:"insecure" json:"insecure"`
+}
+
+// UnixSocketConfig adds a Unix domain socket listener. Mode is an octal file
+// mode such as "0660"; UID and GID change the socket's owner when not -1.
+// Callers are identified by their SO_PEERCRED uid, and when AllowedUIDs is
+// non-empty only those uids may call the server over the socket. Setting
+// serverPort to 0 serves on the socket only.
+type UnixSocketConfig struct {
+	Path        string `key:"path" json:"path"`
+	Mode        string `key:"mode" json:"mode"`
+	UID         int    `key:"uid" json:"uid"`
+	GID         int    `key:"gid" json:"gid"`
+	AllowedUIDs []int  `key:"allowedUids" json:"allowed_uids"`
+}
+
+// AuthConfig enables bearer token authentication. TokenFile is a YAML or JSON
+// file listing the accepted tokens and the RPCs each one may call.
+type AuthConfig struct {
+	Enabled   bool   `key:"enabled" json:"enabled"`
+	TokenFile string `key:"tokenFile" json:"token_file"`
+}
+
+// TokenFile is the format of the file referenced by AuthConfig.TokenFile.
+type TokenFile struct {
+	Tokens []TokenEntry `key:"tokens" json:"tokens"`
+}
+
+// TokenEntry grants a named caller access to a set of RPCs, given by method
+// name (e.g. "Status", "Exec"). A single "*" grants access to every RPC.
+type TokenEntry struct {
+	Name    string   `key:"name" json:"name"`
+	Token   string   `key:"token" json:"token"`
+	Methods []string `key:"methods" json:"methods"`
+}
+
+// AuditConfig enables the audit log: one JSON line per state-changing RPC,
+// written to Path and rotated once it exceeds MaxSizeMB, keeping MaxBackups
+// rotated files. A MaxSizeMB of 0 disables rotation; otherwise MaxBackups must
+// be at least 1, so rotating never discards the records just written.
+type AuditConfig struct {
+	Enabled    bool   `key:"enabled" json:"enabled"`
+	Path       string `key:"path" json:"path"`
+	MaxSizeMB  int    `key:"maxSizeMB" json:"max_size_mb"`
+	MaxBackups int    `key:"maxBackups" json:"max_backups"`
+}
+
+// MetricsConfig exposes Prometheus metrics over HTTP at /metrics on Port.
+type MetricsConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+	Port    uint `key:"port" json:"port"`
+}
+
+// RegistryConfig persists process records to Path so that a restarted server
+// reattaches to the processes still running. While enabled, process output is
+// written to files under LogDir (a "logs" directory next to Path by default)
+// instead of pipes, so it survives the restart as well. MaxExited is how many
+// processes no longer running are kept, in the registry and the process
+// table, with their log files; the oldest are removed beyond it, and 0 keeps
+// them all.
+type RegistryConfig struct {
+	Enabled   bool   `key:"enabled" json:"enabled"`
+	Path      string `key:"path" json:"path"`
+	LogDir    string `key:"logDir" json:"log_dir"`
+	MaxExited int    `key:"maxExited" json:"max_exited"`
+}
+
+// ShutdownConfig decides what happens to running processes when the server
+// receives a termination signal. Policy is "leave" to keep them running,
+// "terminate" to send SIGTERM and SIGKILL whatever is left after
+// GracePeriodS, or "kill" to send SIGKILL right away. Processes are stopped
+// most recently started first.
+type ShutdownConfig struct {
+	Policy       string `key:"policy" json:"policy"`
+	GracePeriodS int    `key:"gracePeriodS" json:"grace_period_s"`
+}
+
+// InitConfig runs the server as an init process, typically as a container
+// entrypoint. It reaps every child including orphaned grandchildren, becomes a
+// child subreaper when it is not PID 1, and forwards ForwardSignals (names such
+// as "SIGHUP", or numbers) to the running processes.
+type InitConfig struct {
+	Enabled        bool     `key:"enabled" json:"enabled"`
+	ForwardSignals []string `key:"forwardSignals" json:"forward_signals"`
+}
+
+// RedactionConfig hides the values of environment variables whose names match
+// any of EnvPatterns, path.Match globs compared ignoring case, wherever the
+// server reports a process's environment.
+type RedactionConfig struct {
+	EnvPatterns []string `key:"envPatterns" json:"env_patterns"`
+}
+
+// GatewayConfig serves every GoProc RPC as JSON over HTTP on Port, using the
+// same TLS settings, authentication and audit log as the gRPC listener.
+// AllowedOrigins lists the origins browsers may call the gateway from in
+// addition to its own; "*" allows any origin.
+type GatewayConfig struct {
+	Enabled        bool     `key:"enabled" json:"enabled"`
+	Port           uint     `key:"port" json:"port"`
+	AllowedOrigins []string `key:"allowedOrigins" json:"allowed_origins"`
+}
+
+// PolicyConfig restricts what Exec will run. A request matching any Deny rule
+// is rejected; when Allow rules are present, a request must also match one of
+// them.
+type PolicyConfig struct {
+	Allow []PolicyRule `key:"allow" json:"allow"`
+	Deny  []PolicyRule `key:"deny" json:"deny"`
+}
+
+// PolicyRule matches exec requests. Executables and EnvNames are path.Match
+// globs, matched against the resolved executable path and environment
+// variable names; an executable pattern without a slash matches the binary's
+// base name. Args are regular expressions that must match a whole
+// argument, and CwdPrefixes are directories the working directory must be in.
+// PathPrefixes are directories that every file the server opens for the
+// process, such as a stdin file, an env file or an output redirect, must be
+// in; relative paths are resolved against the working directory. Only the
+// criteria that are set take part in matching.
+type PolicyRule struct {
+	Name         string   `key:"name" json:"name"`
+	Executables  []string `key:"executables" json:"executables"`
+	Args         []string `key:"args" json:"args"`
+	CwdPrefixes  []string `key:"cwdPrefixes" json:"cwd_prefixes"`
+	EnvNames     []string `key:"envNames" json:"env_names"`
+	PathPrefixes []string `key:"pathPrefixes" json:"path_prefixes"`
 }
@@ -0,0 +1,170 @@
+package goproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// PeerCredAuthInfo identifies a caller connected over the Unix socket by the
+// credentials the kernel reports for the peer process.
+type PeerCredAuthInfo struct {
+	credentials.CommonAuthInfo
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+func (PeerCredAuthInfo) AuthType() string {
+	return "peercred"
+}
+
+func listenUnix(cfg UnixSocketConfig) (net.Listener, error) {
+	// Remove a stale socket left behind by a previous run, but never anything
+	// that isn't a socket.
+	if fi, err := os.Lstat(cfg.Path); err == nil {
+		if fi.Mode().Type() != fs.ModeSocket {
+			return nil, ErrNotUnixSocket
+		}
+
+		if err := os.Remove(cfg.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	l, err := net.Listen("unix", cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Mode != "" {
+		mode, err := strconv.ParseUint(cfg.Mode, 8, 32)
+		if err != nil {
+			l.Close()
+			return nil, fmt.Errorf("invalid unix socket mode %q: %w", cfg.Mode, err)
+		}
+
+		if err := os.Chmod(cfg.Path, os.FileMode(mode)); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+
+	if cfg.UID != -1 || cfg.GID != -1 {
+		if err := os.Chown(cfg.Path, cfg.UID, cfg.GID); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// listenerCredentials serves Unix socket connections without TLS, identifying
+// the peer with SO_PEERCRED, and hands every other connection to the TCP
+// transport credentials.
+type listenerCredentials struct {
+	tcp credentials.TransportCredentials
+}
+
+func newListenerCredentials(tcp credentials.TransportCredentials) credentials.TransportCredentials {
+	return &listenerCredentials{tcp: tcp}
+}
+
+func (c *listenerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if uc, ok := conn.(*net.UnixConn); ok {
+		info, err := peerCredentials(uc)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return conn, info, nil
+	}
+
+	if c.tcp == nil {
+		return nil, nil, ErrTCPDisabled
+	}
+
+	return c.tcp.ServerHandshake(conn)
+}
+
+func (c *listenerCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("listenerCredentials is server-side only")
+}
+
+func (c *listenerCredentials) Info() credentials.ProtocolInfo {
+	if c.tcp == nil {
+		return credentials.ProtocolInfo{SecurityProtocol: "peercred"}
+	}
+
+	return c.tcp.Info()
+}
+
+func (c *listenerCredentials) Clone() credentials.TransportCredentials {
+	if c.tcp == nil {
+		return &listenerCredentials{}
+	}
+
+	return &listenerCredentials{tcp: c.tcp.Clone()}
+}
+
+func (c *listenerCredentials) OverrideServerName(name string) error {
+	return nil
+}
+
+// peerCredChecker logs the uid of callers on the Unix socket and rejects
+// those not in allowedUIDs. Callers on other listeners pass through.
+type peerCredChecker struct {
+	allowedUIDs []int
+}
+
+func (pc *peerCredChecker) check(ctx context.Context, fullMethod string) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	info, ok := p.AuthInfo.(*PeerCredAuthInfo)
+	if !ok {
+		return ctx, nil
+	}
+
+	log.Debug().Uint32("uid", info.UID).Int32("pid", info.PID).Str("method", fullMethod).Msg("Unix socket call")
+
+	ctx = withCaller(ctx, fmt.Sprintf("uid:%d", info.UID))
+	if len(pc.allowedUIDs) > 0 && !slices.Contains(pc.allowedUIDs, int(info.UID)) {
+		return ctx, status.Errorf(codes.PermissionDenied, "uid %d is not allowed", info.UID)
+	}
+
+	return ctx, nil
+}
+
+func (pc *peerCredChecker) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := pc.check(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (pc *peerCredChecker) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := pc.check(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
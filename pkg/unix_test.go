@@ -0,0 +1,88 @@
+package goproc
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListenUnix(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, path string)
+		mode    string
+		err     error
+		wantErr bool
+		perm    fs.FileMode
+	}{
+		{name: "new socket", mode: "0600", perm: 0600},
+		{name: "stale socket", mode: "0660", perm: 0660, setup: func(t *testing.T, path string) {
+			l, err := net.Listen("unix", path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Leave the socket file behind, as a crashed server would.
+			l.(*net.UnixListener).SetUnlinkOnClose(false)
+			l.Close()
+		}},
+		{name: "regular file", err: ErrNotUnixSocket, setup: func(t *testing.T, path string) {
+			if err := os.WriteFile(path, []byte("keep me"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{name: "bad mode", mode: "rw-rw----", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "goproc.sock")
+			if tt.setup != nil {
+				tt.setup(t, path)
+			}
+
+			l, err := listenUnix(UnixSocketConfig{Path: path, Mode: tt.mode, UID: -1, GID: -1})
+			if tt.err != nil || tt.wantErr {
+				if err == nil {
+					l.Close()
+					t.Fatal("expected an error")
+				}
+				if tt.err != nil && !errors.Is(err, tt.err) {
+					t.Fatalf("got %v, want %v", err, tt.err)
+				}
+				if _, err := os.Stat(path); tt.setup != nil && err != nil {
+					t.Errorf("removed %s: %v", path, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+
+			fi, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fi.Mode().Type() != fs.ModeSocket {
+				t.Errorf("got file type %v, want a socket", fi.Mode().Type())
+			}
+			if fi.Mode().Perm() != tt.perm {
+				t.Errorf("got mode %v, want %v", fi.Mode().Perm(), tt.perm)
+			}
+		})
+	}
+}
+
+func TestListenerCredentialsWithoutTCP(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	_, _, err := newListenerCredentials(nil).ServerHandshake(server)
+	if !errors.Is(err, ErrTCPDisabled) {
+		t.Fatalf("got %v, want %v", err, ErrTCPDisabled)
+	}
+}
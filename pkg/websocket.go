@@ -0,0 +1,211 @@
+package goproc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/beam-cloud/goproc/proto"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// accessTokenParam carries the bearer token for browsers, which cannot
+	// set headers on a WebSocket handshake.
+	accessTokenParam = "access_token"
+	stdinField       = "stdin"
+
+	// websocketCloseCodeBase is added to the gRPC code of a failed call to
+	// form the close code, in the range reserved for applications.
+	websocketCloseCodeBase = 4000
+	websocketCloseTimeout  = time.Second
+	maxCloseReasonBytes    = 123
+)
+
+func (g *gateway) websocketHandler(pattern string, desc grpc.StreamDesc) http.HandlerFunc {
+	fullMethod := fmt.Sprintf("/%s/%s", proto.GoProc_ServiceDesc.ServiceName, desc.StreamName)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The first message is built from the query before the upgrade, so
+		// only a handshake, which browsers send with an Origin, may carry it.
+		if !websocket.IsWebSocketUpgrade(r) {
+			writeGatewayError(w, status.Error(codes.InvalidArgument, "this call is only served over a WebSocket"))
+			return
+		}
+
+		query := r.URL.Query()
+		if token := query.Get(accessTokenParam); token != "" {
+			if r.Header.Get("Authorization") == "" {
+				r.Header.Set("Authorization", bearerPrefix+token)
+			}
+			query.Del(accessTokenParam)
+			r.URL.RawQuery = query.Encode()
+		}
+
+		ctx, cancel := context.WithCancel(g.incomingContext(r))
+		defer cancel()
+
+		stream := &websocketServerStream{
+			ctx:      ctx,
+			cancel:   cancel,
+			w:        w,
+			r:        r,
+			pattern:  pattern,
+			upgrader: g.upgrader,
+		}
+
+		info := &grpc.StreamServerInfo{
+			FullMethod:     fullMethod,
+			IsServerStream: desc.ServerStreams,
+			IsClientStream: desc.ClientStreams,
+		}
+
+		err := g.stream(g.cs, stream, info, desc.Handler)
+		if !stream.upgraded() {
+			if err != nil {
+				writeGatewayError(w, err)
+			}
+			return
+		}
+
+		stream.close(err)
+	}
+}
+
+// websocketServerStream adapts a WebSocket to grpc.ServerStream for
+// bidirectional RPCs. The first message received is built from the path and
+// query parameters; later ones come from the socket, where text frames carry
+// the JSON form of the request and binary frames raw stdin. Responses are sent
+// as JSON text frames.
+//
+// The connection is only upgraded once the handler first sends or reads from
+// the socket, so calls rejected by an interceptor or failing up front still
+// get a plain HTTP error.
+type websocketServerStream struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	w        http.ResponseWriter
+	r        *http.Request
+	pattern  string
+	upgrader *websocket.Upgrader
+	received bool
+
+	mu         sync.Mutex
+	conn       *websocket.Conn
+	upgradeErr error
+	writeMu    sync.Mutex
+}
+
+func (s *websocketServerStream) SetHeader(metadata.MD) error  { return nil }
+func (s *websocketServerStream) SendHeader(metadata.MD) error { return nil }
+func (s *websocketServerStream) SetTrailer(metadata.MD)       {}
+
+func (s *websocketServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *websocketServerStream) upgrade() (*websocket.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil && s.upgradeErr == nil {
+		s.conn, s.upgradeErr = s.upgrader.Upgrade(s.w, s.r, nil)
+	}
+
+	return s.conn, s.upgradeErr
+}
+
+func (s *websocketServerStream) upgraded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn != nil || s.upgradeErr != nil
+}
+
+func (s *websocketServerStream) RecvMsg(m any) error {
+	msg := m.(protobuf.Message)
+
+	if !s.received {
+		s.received = true
+		return decodeGatewayRequest(s.r, s.pattern, msg)
+	}
+
+	conn, err := s.upgrade()
+	if err != nil {
+		return err
+	}
+
+	typ, data, err := conn.ReadMessage()
+	if err != nil {
+		// The caller is gone, so stop the handler as a gRPC stream would.
+		s.cancel()
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			return io.EOF
+		}
+		return err
+	}
+
+	if typ == websocket.BinaryMessage {
+		fd := msg.ProtoReflect().Descriptor().Fields().ByName(stdinField)
+		if fd == nil || fd.Kind() != protoreflect.BytesKind {
+			return status.Error(codes.InvalidArgument, "binary messages are not supported by this call")
+		}
+
+		msg.ProtoReflect().Set(fd, protoreflect.ValueOfBytes(data))
+		return nil
+	}
+
+	if err := gatewayUnmarshal.Unmarshal(data, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid message: %v", err)
+	}
+
+	return nil
+}
+
+func (s *websocketServerStream) SendMsg(m any) error {
+	conn, err := s.upgrade()
+	if err != nil {
+		return err
+	}
+
+	data, err := gatewayMarshal.Marshal(m.(protobuf.Message))
+	if err != nil {
+		return err
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// close ends the WebSocket with a close frame. A failed call closes with
+// websocketCloseCodeBase plus its gRPC code and the status message as reason.
+func (s *websocketServerStream) close(err error) {
+	conn, upgradeErr := s.upgrade()
+	if upgradeErr != nil {
+		return
+	}
+
+	code, reason := websocket.CloseNormalClosure, ""
+	if err != nil {
+		st := status.Convert(err)
+		code, reason = websocketCloseCodeBase+int(st.Code()), st.Message()
+		if len(reason) > maxCloseReasonBytes {
+			reason = reason[:maxCloseReasonBytes]
+		}
+	}
+
+	s.writeMu.Lock()
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(websocketCloseTimeout))
+	s.writeMu.Unlock()
+
+	conn.Close()
+}
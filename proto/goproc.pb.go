@@ -20,6 +20,337 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EnvMode int32
+
+const (
+	// Same as ENV_MODE_REPLACE, except that a process given no variables at
+	// all gets the server's environment.
+	EnvMode_ENV_MODE_UNSPECIFIED EnvMode = 0
+	// The process gets env and nothing else.
+	EnvMode_ENV_MODE_REPLACE EnvMode = 1
+	// The server's environment, with env overriding variables of the same name.
+	EnvMode_ENV_MODE_INHERIT_AND_OVERRIDE EnvMode = 2
+	// The server variables matching env_allowlist, overridden by env.
+	EnvMode_ENV_MODE_INHERIT_ALLOWLIST EnvMode = 3
+)
+
+// Enum value maps for EnvMode.
+var (
+	EnvMode_name = map[int32]string{
+		0: "ENV_MODE_UNSPECIFIED",
+		1: "ENV_MODE_REPLACE",
+		2: "ENV_MODE_INHERIT_AND_OVERRIDE",
+		3: "ENV_MODE_INHERIT_ALLOWLIST",
+	}
+	EnvMode_value = map[string]int32{
+		"ENV_MODE_UNSPECIFIED":          0,
+		"ENV_MODE_REPLACE":              1,
+		"ENV_MODE_INHERIT_AND_OVERRIDE": 2,
+		"ENV_MODE_INHERIT_ALLOWLIST":    3,
+	}
+)
+
+func (x EnvMode) Enum() *EnvMode {
+	p := new(EnvMode)
+	*p = x
+	return p
+}
+
+func (x EnvMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnvMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_goproc_proto_enumTypes[0].Descriptor()
+}
+
+func (EnvMode) Type() protoreflect.EnumType {
+	return &file_goproc_proto_enumTypes[0]
+}
+
+func (x EnvMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EnvMode.Descriptor instead.
+func (EnvMode) EnumDescriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{0}
+}
+
+type OutputTargetType int32
+
+const (
+	// Same as OUTPUT_TARGET_BUFFER.
+	OutputTargetType_OUTPUT_TARGET_UNSPECIFIED OutputTargetType = 0
+	OutputTargetType_OUTPUT_TARGET_BUFFER      OutputTargetType = 1
+	// Write straight to a file on the server, never buffered.
+	OutputTargetType_OUTPUT_TARGET_FILE    OutputTargetType = 2
+	OutputTargetType_OUTPUT_TARGET_DISCARD OutputTargetType = 3
+	// Send stderr wherever stdout goes, like 2>&1. Only valid for stderr.
+	OutputTargetType_OUTPUT_TARGET_MERGE OutputTargetType = 4
+)
+
+// Enum value maps for OutputTargetType.
+var (
+	OutputTargetType_name = map[int32]string{
+		0: "OUTPUT_TARGET_UNSPECIFIED",
+		1: "OUTPUT_TARGET_BUFFER",
+		2: "OUTPUT_TARGET_FILE",
+		3: "OUTPUT_TARGET_DISCARD",
+		4: "OUTPUT_TARGET_MERGE",
+	}
+	OutputTargetType_value = map[string]int32{
+		"OUTPUT_TARGET_UNSPECIFIED": 0,
+		"OUTPUT_TARGET_BUFFER":      1,
+		"OUTPUT_TARGET_FILE":        2,
+		"OUTPUT_TARGET_DISCARD":     3,
+		"OUTPUT_TARGET_MERGE":       4,
+	}
+)
+
+func (x OutputTargetType) Enum() *OutputTargetType {
+	p := new(OutputTargetType)
+	*p = x
+	return p
+}
+
+func (x OutputTargetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputTargetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_goproc_proto_enumTypes[1].Descriptor()
+}
+
+func (OutputTargetType) Type() protoreflect.EnumType {
+	return &file_goproc_proto_enumTypes[1]
+}
+
+func (x OutputTargetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputTargetType.Descriptor instead.
+func (OutputTargetType) EnumDescriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{1}
+}
+
+type ProcessOrder int32
+
+const (
+	// Same as PROCESS_ORDER_START_TIME.
+	ProcessOrder_PROCESS_ORDER_UNSPECIFIED ProcessOrder = 0
+	ProcessOrder_PROCESS_ORDER_START_TIME  ProcessOrder = 1
+	ProcessOrder_PROCESS_ORDER_PID         ProcessOrder = 2
+	// By name, then start time.
+	ProcessOrder_PROCESS_ORDER_NAME ProcessOrder = 3
+)
+
+// Enum value maps for ProcessOrder.
+var (
+	ProcessOrder_name = map[int32]string{
+		0: "PROCESS_ORDER_UNSPECIFIED",
+		1: "PROCESS_ORDER_START_TIME",
+		2: "PROCESS_ORDER_PID",
+		3: "PROCESS_ORDER_NAME",
+	}
+	ProcessOrder_value = map[string]int32{
+		"PROCESS_ORDER_UNSPECIFIED": 0,
+		"PROCESS_ORDER_START_TIME":  1,
+		"PROCESS_ORDER_PID":         2,
+		"PROCESS_ORDER_NAME":        3,
+	}
+)
+
+func (x ProcessOrder) Enum() *ProcessOrder {
+	p := new(ProcessOrder)
+	*p = x
+	return p
+}
+
+func (x ProcessOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProcessOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_goproc_proto_enumTypes[2].Descriptor()
+}
+
+func (ProcessOrder) Type() protoreflect.EnumType {
+	return &file_goproc_proto_enumTypes[2]
+}
+
+func (x ProcessOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProcessOrder.Descriptor instead.
+func (ProcessOrder) EnumDescriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{2}
+}
+
+type ProcessState int32
+
+const (
+	ProcessState_PROCESS_STATE_UNSPECIFIED ProcessState = 0
+	ProcessState_PROCESS_STATE_RUNNING     ProcessState = 1
+	ProcessState_PROCESS_STATE_EXITED      ProcessState = 2
+	// The process was running when the server stopped and had vanished by the
+	// time it restarted, so its exit code is unknown.
+	ProcessState_PROCESS_STATE_LOST ProcessState = 3
+)
+
+// Enum value maps for ProcessState.
+var (
+	ProcessState_name = map[int32]string{
+		0: "PROCESS_STATE_UNSPECIFIED",
+		1: "PROCESS_STATE_RUNNING",
+		2: "PROCESS_STATE_EXITED",
+		3: "PROCESS_STATE_LOST",
+	}
+	ProcessState_value = map[string]int32{
+		"PROCESS_STATE_UNSPECIFIED": 0,
+		"PROCESS_STATE_RUNNING":     1,
+		"PROCESS_STATE_EXITED":      2,
+		"PROCESS_STATE_LOST":        3,
+	}
+)
+
+func (x ProcessState) Enum() *ProcessState {
+	p := new(ProcessState)
+	*p = x
+	return p
+}
+
+func (x ProcessState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProcessState) Descriptor() protoreflect.EnumDescriptor {
+	return file_goproc_proto_enumTypes[3].Descriptor()
+}
+
+func (ProcessState) Type() protoreflect.EnumType {
+	return &file_goproc_proto_enumTypes[3]
+}
+
+func (x ProcessState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProcessState.Descriptor instead.
+func (ProcessState) EnumDescriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{3}
+}
+
+// REMOVED is sent when a process no longer running is dropped from the process
+// table under the registry's retention limit. There are no restarted or
+// health changed events: the server doesn't restart or probe processes. Their
+// numbers are kept free for process supervision.
+type ProcessEventType int32
+
+const (
+	ProcessEventType_PROCESS_EVENT_UNSPECIFIED ProcessEventType = 0
+	ProcessEventType_PROCESS_EVENT_STARTED     ProcessEventType = 1
+	ProcessEventType_PROCESS_EVENT_EXITED      ProcessEventType = 2
+	ProcessEventType_PROCESS_EVENT_SIGNALED    ProcessEventType = 3
+	ProcessEventType_PROCESS_EVENT_REMOVED     ProcessEventType = 6
+)
+
+// Enum value maps for ProcessEventType.
+var (
+	ProcessEventType_name = map[int32]string{
+		0: "PROCESS_EVENT_UNSPECIFIED",
+		1: "PROCESS_EVENT_STARTED",
+		2: "PROCESS_EVENT_EXITED",
+		3: "PROCESS_EVENT_SIGNALED",
+		6: "PROCESS_EVENT_REMOVED",
+	}
+	ProcessEventType_value = map[string]int32{
+		"PROCESS_EVENT_UNSPECIFIED": 0,
+		"PROCESS_EVENT_STARTED":     1,
+		"PROCESS_EVENT_EXITED":      2,
+		"PROCESS_EVENT_SIGNALED":    3,
+		"PROCESS_EVENT_REMOVED":     6,
+	}
+)
+
+func (x ProcessEventType) Enum() *ProcessEventType {
+	p := new(ProcessEventType)
+	*p = x
+	return p
+}
+
+func (x ProcessEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProcessEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_goproc_proto_enumTypes[4].Descriptor()
+}
+
+func (ProcessEventType) Type() protoreflect.EnumType {
+	return &file_goproc_proto_enumTypes[4]
+}
+
+func (x ProcessEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProcessEventType.Descriptor instead.
+func (ProcessEventType) EnumDescriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{4}
+}
+
+type OutputStream int32
+
+const (
+	OutputStream_OUTPUT_STREAM_UNSPECIFIED OutputStream = 0
+	OutputStream_OUTPUT_STREAM_STDOUT      OutputStream = 1
+	OutputStream_OUTPUT_STREAM_STDERR      OutputStream = 2
+)
+
+// Enum value maps for OutputStream.
+var (
+	OutputStream_name = map[int32]string{
+		0: "OUTPUT_STREAM_UNSPECIFIED",
+		1: "OUTPUT_STREAM_STDOUT",
+		2: "OUTPUT_STREAM_STDERR",
+	}
+	OutputStream_value = map[string]int32{
+		"OUTPUT_STREAM_UNSPECIFIED": 0,
+		"OUTPUT_STREAM_STDOUT":      1,
+		"OUTPUT_STREAM_STDERR":      2,
+	}
+)
+
+func (x OutputStream) Enum() *OutputStream {
+	p := new(OutputStream)
+	*p = x
+	return p
+}
+
+func (x OutputStream) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputStream) Descriptor() protoreflect.EnumDescriptor {
+	return file_goproc_proto_enumTypes[5].Descriptor()
+}
+
+func (OutputStream) Type() protoreflect.EnumType {
+	return &file_goproc_proto_enumTypes[5]
+}
+
+func (x OutputStream) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputStream.Descriptor instead.
+func (OutputStream) EnumDescriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{5}
+}
+
 type ExecProcessRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -29,6 +360,55 @@ type ExecProcessRequest struct {
 	Cwd  string   `protobuf:"bytes,2,opt,name=cwd,proto3" json:"cwd,omitempty"`
 	Env  []string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`
 	Wait *bool    `protobuf:"varint,4,opt,name=wait,proto3,oneof" json:"wait,omitempty"`
+	// Keep stdin open so it can be written through Attach. Otherwise the
+	// process reads from /dev/null.
+	Stdin bool `protobuf:"varint,5,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// Run the process in a pseudo-terminal. Stdout and stderr are merged and
+	// stdin is always open.
+	Tty bool `protobuf:"varint,6,opt,name=tty,proto3" json:"tty,omitempty"`
+	// Initial terminal size when tty is set. Defaults to 24x80.
+	TerminalSize *TerminalSize `protobuf:"bytes,7,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
+	// Requests repeating the key of an earlier one from the same caller get its
+	// response instead of starting another process, for as long as the server
+	// remembers the key.
+	IdempotencyKey string `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Run a pipeline instead of args. Every stage runs with cwd and env, in a
+	// process group of its own whose leader, the first stage, is the pid
+	// reported for the pipeline. Signals are sent to the whole group.
+	Pipeline *Pipeline `protobuf:"bytes,9,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	// Where stdout and stderr go. By default both are buffered for Stdout,
+	// Stderr and StreamOutput. Only the default is allowed with tty.
+	Stdout *OutputTarget `protobuf:"bytes,10,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr *OutputTarget `protobuf:"bytes,11,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// Bytes written to stdin, which is then closed. For payloads too large for
+	// a single message use ExecUpload.
+	StdinData []byte `protobuf:"bytes,12,opt,name=stdin_data,json=stdinData,proto3" json:"stdin_data,omitempty"`
+	// File on the server read as stdin. Relative paths are resolved against
+	// cwd. At most one of stdin, stdin_data, stdin_file and the pipeline's
+	// stdin_file may be set, and none of the last three with tty.
+	StdinFile string `protobuf:"bytes,13,opt,name=stdin_file,json=stdinFile,proto3" json:"stdin_file,omitempty"`
+	// How env is combined with the server's environment.
+	EnvMode EnvMode `protobuf:"varint,14,opt,name=env_mode,json=envMode,proto3,enum=goproc.EnvMode" json:"env_mode,omitempty"`
+	// Glob patterns of the server variables passed on with
+	// ENV_MODE_INHERIT_ALLOWLIST.
+	EnvAllowlist []string `protobuf:"bytes,15,rep,name=env_allowlist,json=envAllowlist,proto3" json:"env_allowlist,omitempty"`
+	// Expand ${VAR} in env values against the server's environment. Unset
+	// variables expand to nothing.
+	ExpandEnv bool `protobuf:"varint,16,opt,name=expand_env,json=expandEnv,proto3" json:"expand_env,omitempty"`
+	// Variables whose values are read from files on the server, such as
+	// mounted secrets, with one trailing newline removed. They override env
+	// and are never echoed back.
+	EnvFiles map[string]string `protobuf:"bytes,17,rep,name=env_files,json=envFiles,proto3" json:"env_files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Glob patterns of variables whose values are redacted wherever the
+	// environment is reported, in addition to the server's redaction rules.
+	// Matched ignoring case.
+	SensitiveEnv []string `protobuf:"bytes,18,rep,name=sensitive_env,json=sensitiveEnv,proto3" json:"sensitive_env,omitempty"`
+	// A name for people to recognize the process by. It need not be unique.
+	Name string `protobuf:"bytes,19,opt,name=name,proto3" json:"name,omitempty"`
+	// Labels to select the process by in ListProcesses. Keys are alphanumeric
+	// with "-", "_", "." and "/" inside; values are the same without "/" and
+	// may be empty.
+	Labels map[string]string `protobuf:"bytes,20,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ExecProcessRequest) Reset() {
@@ -58,67 +438,1383 @@ func (x *ExecProcessRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecProcessRequest.ProtoReflect.Descriptor instead.
-func (*ExecProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ExecProcessRequest.ProtoReflect.Descriptor instead.
+func (*ExecProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExecProcessRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetCwd() string {
+	if x != nil {
+		return x.Cwd
+	}
+	return ""
+}
+
+func (x *ExecProcessRequest) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetWait() bool {
+	if x != nil && x.Wait != nil {
+		return *x.Wait
+	}
+	return false
+}
+
+func (x *ExecProcessRequest) GetStdin() bool {
+	if x != nil {
+		return x.Stdin
+	}
+	return false
+}
+
+func (x *ExecProcessRequest) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *ExecProcessRequest) GetTerminalSize() *TerminalSize {
+	if x != nil {
+		return x.TerminalSize
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ExecProcessRequest) GetPipeline() *Pipeline {
+	if x != nil {
+		return x.Pipeline
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetStdout() *OutputTarget {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetStderr() *OutputTarget {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetStdinData() []byte {
+	if x != nil {
+		return x.StdinData
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetStdinFile() string {
+	if x != nil {
+		return x.StdinFile
+	}
+	return ""
+}
+
+func (x *ExecProcessRequest) GetEnvMode() EnvMode {
+	if x != nil {
+		return x.EnvMode
+	}
+	return EnvMode_ENV_MODE_UNSPECIFIED
+}
+
+func (x *ExecProcessRequest) GetEnvAllowlist() []string {
+	if x != nil {
+		return x.EnvAllowlist
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetExpandEnv() bool {
+	if x != nil {
+		return x.ExpandEnv
+	}
+	return false
+}
+
+func (x *ExecProcessRequest) GetEnvFiles() map[string]string {
+	if x != nil {
+		return x.EnvFiles
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetSensitiveEnv() []string {
+	if x != nil {
+		return x.SensitiveEnv
+	}
+	return nil
+}
+
+func (x *ExecProcessRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExecProcessRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// The first message of an ExecUpload carries the request and may carry the
+// first chunk of stdin; the rest carry only stdin. Stdin is closed when the
+// client closes its side of the stream.
+type ExecUploadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exec  *ExecProcessRequest `protobuf:"bytes,1,opt,name=exec,proto3" json:"exec,omitempty"`
+	Stdin []byte              `protobuf:"bytes,2,opt,name=stdin,proto3" json:"stdin,omitempty"`
+}
+
+func (x *ExecUploadRequest) Reset() {
+	*x = ExecUploadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecUploadRequest) ProtoMessage() {}
+
+func (x *ExecUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecUploadRequest.ProtoReflect.Descriptor instead.
+func (*ExecUploadRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExecUploadRequest) GetExec() *ExecProcessRequest {
+	if x != nil {
+		return x.Exec
+	}
+	return nil
+}
+
+func (x *ExecUploadRequest) GetStdin() []byte {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+type OutputTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type OutputTargetType `protobuf:"varint,1,opt,name=type,proto3,enum=goproc.OutputTargetType" json:"type,omitempty"`
+	// The file for OUTPUT_TARGET_FILE.
+	File *Redirect `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (x *OutputTarget) Reset() {
+	*x = OutputTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputTarget) ProtoMessage() {}
+
+func (x *OutputTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputTarget.ProtoReflect.Descriptor instead.
+func (*OutputTarget) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OutputTarget) GetType() OutputTargetType {
+	if x != nil {
+		return x.Type
+	}
+	return OutputTargetType_OUTPUT_TARGET_UNSPECIFIED
+}
+
+func (x *OutputTarget) GetFile() *Redirect {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// Stages are connected stdout to stdin by pipes. The exit code of a pipeline
+// is that of its last stage to fail, or 0 if every stage succeeded, like a
+// shell with pipefail set.
+type Pipeline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stages []*PipelineStage `protobuf:"bytes,1,rep,name=stages,proto3" json:"stages,omitempty"`
+	// File the first stage reads stdin from. Relative paths are resolved
+	// against cwd.
+	StdinFile string `protobuf:"bytes,2,opt,name=stdin_file,json=stdinFile,proto3" json:"stdin_file,omitempty"`
+	// File the last stage's stdout is written to instead of being captured.
+	Stdout *Redirect `protobuf:"bytes,3,opt,name=stdout,proto3" json:"stdout,omitempty"`
+}
+
+func (x *Pipeline) Reset() {
+	*x = Pipeline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pipeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pipeline) ProtoMessage() {}
+
+func (x *Pipeline) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pipeline.ProtoReflect.Descriptor instead.
+func (*Pipeline) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pipeline) GetStages() []*PipelineStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *Pipeline) GetStdinFile() string {
+	if x != nil {
+		return x.StdinFile
+	}
+	return ""
+}
+
+func (x *Pipeline) GetStdout() *Redirect {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+type PipelineStage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Args []string `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	// File this stage's stderr is written to instead of being captured.
+	Stderr *Redirect `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+}
+
+func (x *PipelineStage) Reset() {
+	*x = PipelineStage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PipelineStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineStage) ProtoMessage() {}
+
+func (x *PipelineStage) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PipelineStage.ProtoReflect.Descriptor instead.
+func (*PipelineStage) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PipelineStage) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *PipelineStage) GetStderr() *Redirect {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+type Redirect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Append to the file instead of truncating it.
+	Append bool `protobuf:"varint,2,opt,name=append,proto3" json:"append,omitempty"`
+	// Permission bits of the file if it is created. Defaults to 0644.
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *Redirect) Reset() {
+	*x = Redirect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Redirect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redirect) ProtoMessage() {}
+
+func (x *Redirect) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redirect.ProtoReflect.Descriptor instead.
+func (*Redirect) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Redirect) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Redirect) GetAppend() bool {
+	if x != nil {
+		return x.Append
+	}
+	return false
+}
+
+func (x *Redirect) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+type TerminalSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows uint32 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols uint32 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+}
+
+func (x *TerminalSize) Reset() {
+	*x = TerminalSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TerminalSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminalSize) ProtoMessage() {}
+
+func (x *TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TerminalSize) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *TerminalSize) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+type ExecProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Pid      int32  `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	ErrorMsg string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	ExitCode *int32 `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
+	// Exit code of every pipeline stage, set with exit_code.
+	StageExitCodes []int32 `protobuf:"varint,5,rep,packed,name=stage_exit_codes,json=stageExitCodes,proto3" json:"stage_exit_codes,omitempty"`
+}
+
+func (x *ExecProcessResponse) Reset() {
+	*x = ExecProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecProcessResponse) ProtoMessage() {}
+
+func (x *ExecProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecProcessResponse.ProtoReflect.Descriptor instead.
+func (*ExecProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ExecProcessResponse) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ExecProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *ExecProcessResponse) GetExitCode() int32 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
+	}
+	return 0
+}
+
+func (x *ExecProcessResponse) GetStageExitCodes() []int32 {
+	if x != nil {
+		return x.StageExitCodes
+	}
+	return nil
+}
+
+type WaitProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+}
+
+func (x *WaitProcessRequest) Reset() {
+	*x = WaitProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitProcessRequest) ProtoMessage() {}
+
+func (x *WaitProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitProcessRequest.ProtoReflect.Descriptor instead.
+func (*WaitProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WaitProcessRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type WaitProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ExitCode int32  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	ErrorMsg string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	// Exit code of every pipeline stage.
+	StageExitCodes []int32 `protobuf:"varint,4,rep,packed,name=stage_exit_codes,json=stageExitCodes,proto3" json:"stage_exit_codes,omitempty"`
+}
+
+func (x *WaitProcessResponse) Reset() {
+	*x = WaitProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitProcessResponse) ProtoMessage() {}
+
+func (x *WaitProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitProcessResponse.ProtoReflect.Descriptor instead.
+func (*WaitProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WaitProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *WaitProcessResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *WaitProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *WaitProcessResponse) GetStageExitCodes() []int32 {
+	if x != nil {
+		return x.StageExitCodes
+	}
+	return nil
+}
+
+type KillProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+}
+
+func (x *KillProcessRequest) Reset() {
+	*x = KillProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KillProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillProcessRequest) ProtoMessage() {}
+
+func (x *KillProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KillProcessRequest.ProtoReflect.Descriptor instead.
+func (*KillProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *KillProcessRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type KillProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *KillProcessResponse) Reset() {
+	*x = KillProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KillProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillProcessResponse) ProtoMessage() {}
+
+func (x *KillProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KillProcessResponse.ProtoReflect.Descriptor instead.
+func (*KillProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *KillProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *KillProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type SignalProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid    int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
+}
+
+func (x *SignalProcessRequest) Reset() {
+	*x = SignalProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalProcessRequest) ProtoMessage() {}
+
+func (x *SignalProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalProcessRequest.ProtoReflect.Descriptor instead.
+func (*SignalProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SignalProcessRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *SignalProcessRequest) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+type SignalProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *SignalProcessResponse) Reset() {
+	*x = SignalProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalProcessResponse) ProtoMessage() {}
+
+func (x *SignalProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalProcessResponse.ProtoReflect.Descriptor instead.
+func (*SignalProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignalProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *SignalProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type StatusProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+}
+
+func (x *StatusProcessRequest) Reset() {
+	*x = StatusProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusProcessRequest) ProtoMessage() {}
+
+func (x *StatusProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusProcessRequest.ProtoReflect.Descriptor instead.
+func (*StatusProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StatusProcessRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type StatusProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool         `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Process  *ProcessInfo `protobuf:"bytes,2,opt,name=process,proto3" json:"process,omitempty"`
+	ErrorMsg string       `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *StatusProcessResponse) Reset() {
+	*x = StatusProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusProcessResponse) ProtoMessage() {}
+
+func (x *StatusProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusProcessResponse.ProtoReflect.Descriptor instead.
+func (*StatusProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StatusProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *StatusProcessResponse) GetProcess() *ProcessInfo {
+	if x != nil {
+		return x.Process
+	}
+	return nil
+}
+
+func (x *StatusProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type StdoutProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+}
+
+func (x *StdoutProcessRequest) Reset() {
+	*x = StdoutProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StdoutProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StdoutProcessRequest) ProtoMessage() {}
+
+func (x *StdoutProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StdoutProcessRequest.ProtoReflect.Descriptor instead.
+func (*StdoutProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StdoutProcessRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type StdoutProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	Stdout   string `protobuf:"bytes,3,opt,name=stdout,proto3" json:"stdout,omitempty"`
+}
+
+func (x *StdoutProcessResponse) Reset() {
+	*x = StdoutProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StdoutProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StdoutProcessResponse) ProtoMessage() {}
+
+func (x *StdoutProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StdoutProcessResponse.ProtoReflect.Descriptor instead.
+func (*StdoutProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StdoutProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *StdoutProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *StdoutProcessResponse) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+type StderrProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+}
+
+func (x *StderrProcessRequest) Reset() {
+	*x = StderrProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StderrProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StderrProcessRequest) ProtoMessage() {}
+
+func (x *StderrProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StderrProcessRequest.ProtoReflect.Descriptor instead.
+func (*StderrProcessRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StderrProcessRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+type StderrProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	Stderr   string `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+}
+
+func (x *StderrProcessResponse) Reset() {
+	*x = StderrProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StderrProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StderrProcessResponse) ProtoMessage() {}
+
+func (x *StderrProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StderrProcessResponse.ProtoReflect.Descriptor instead.
+func (*StderrProcessResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StderrProcessResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *StderrProcessResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *StderrProcessResponse) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+type ListProcessesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Comma-separated requirements that all have to hold: "key=value" (or
+	// "key==value"), "key!=value", "key" for a label that is set and "!key"
+	// for one that is not.
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// Only list processes in this state. Unspecified lists every state.
+	State ProcessState `protobuf:"varint,2,opt,name=state,proto3,enum=goproc.ProcessState" json:"state,omitempty"`
+	// Only list processes whose name starts with this.
+	NamePrefix string       `protobuf:"bytes,3,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	OrderBy    ProcessOrder `protobuf:"varint,4,opt,name=order_by,json=orderBy,proto3,enum=goproc.ProcessOrder" json:"order_by,omitempty"`
+	Descending bool         `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Maximum number of processes to return. Zero returns every match.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page. The other fields must be the same
+	// as for that page.
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListProcessesRequest) Reset() {
+	*x = ListProcessesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_goproc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProcessesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProcessesRequest) ProtoMessage() {}
+
+func (x *ListProcessesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProcessesRequest.ProtoReflect.Descriptor instead.
+func (*ListProcessesRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListProcessesRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
 }
 
-func (x *ExecProcessRequest) GetArgs() []string {
+func (x *ListProcessesRequest) GetState() ProcessState {
 	if x != nil {
-		return x.Args
+		return x.State
 	}
-	return nil
+	return ProcessState_PROCESS_STATE_UNSPECIFIED
 }
 
-func (x *ExecProcessRequest) GetCwd() string {
+func (x *ListProcessesRequest) GetNamePrefix() string {
 	if x != nil {
-		return x.Cwd
+		return x.NamePrefix
 	}
 	return ""
 }
 
-func (x *ExecProcessRequest) GetEnv() []string {
+func (x *ListProcessesRequest) GetOrderBy() ProcessOrder {
 	if x != nil {
-		return x.Env
+		return x.OrderBy
 	}
-	return nil
+	return ProcessOrder_PROCESS_ORDER_UNSPECIFIED
 }
 
-func (x *ExecProcessRequest) GetWait() bool {
-	if x != nil && x.Wait != nil {
-		return *x.Wait
+func (x *ListProcessesRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
 	}
 	return false
 }
 
-type ExecProcessResponse struct {
+func (x *ListProcessesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProcessesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// KillMatching and SignalMatching act on every running process whose labels
+// match label_selector, which has the syntax of
+// ListProcessesRequest.label_selector and must not be empty. No process starts
+// while they run, so none that matches is missed.
+type KillMatchingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Pid      int32  `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
-	ErrorMsg string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
-	ExitCode *int32 `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 }
 
-func (x *ExecProcessResponse) Reset() {
-	*x = ExecProcessResponse{}
+func (x *KillMatchingRequest) Reset() {
+	*x = KillMatchingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[1]
+		mi := &file_goproc_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExecProcessResponse) String() string {
+func (x *KillMatchingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecProcessResponse) ProtoMessage() {}
+func (*KillMatchingRequest) ProtoMessage() {}
 
-func (x *ExecProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[1]
+func (x *KillMatchingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -129,64 +1825,44 @@ func (x *ExecProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecProcessResponse.ProtoReflect.Descriptor instead.
-func (*ExecProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *ExecProcessResponse) GetOk() bool {
-	if x != nil {
-		return x.Ok
-	}
-	return false
-}
-
-func (x *ExecProcessResponse) GetPid() int32 {
-	if x != nil {
-		return x.Pid
-	}
-	return 0
+// Deprecated: Use KillMatchingRequest.ProtoReflect.Descriptor instead.
+func (*KillMatchingRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ExecProcessResponse) GetErrorMsg() string {
+func (x *KillMatchingRequest) GetLabelSelector() string {
 	if x != nil {
-		return x.ErrorMsg
+		return x.LabelSelector
 	}
 	return ""
 }
 
-func (x *ExecProcessResponse) GetExitCode() int32 {
-	if x != nil && x.ExitCode != nil {
-		return *x.ExitCode
-	}
-	return 0
-}
-
-type WaitProcessRequest struct {
+type SignalMatchingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	Signal        int32  `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
 }
 
-func (x *WaitProcessRequest) Reset() {
-	*x = WaitProcessRequest{}
+func (x *SignalMatchingRequest) Reset() {
+	*x = SignalMatchingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[2]
+		mi := &file_goproc_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WaitProcessRequest) String() string {
+func (x *SignalMatchingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WaitProcessRequest) ProtoMessage() {}
+func (*SignalMatchingRequest) ProtoMessage() {}
 
-func (x *WaitProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[2]
+func (x *SignalMatchingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,45 +1873,55 @@ func (x *WaitProcessRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WaitProcessRequest.ProtoReflect.Descriptor instead.
-func (*WaitProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use SignalMatchingRequest.ProtoReflect.Descriptor instead.
+func (*SignalMatchingRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *WaitProcessRequest) GetPid() int32 {
+func (x *SignalMatchingRequest) GetLabelSelector() string {
 	if x != nil {
-		return x.Pid
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *SignalMatchingRequest) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
 	}
 	return 0
 }
 
-type WaitProcessResponse struct {
+type SignalResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	ExitCode int32  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Pid      int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ok       bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
 	ErrorMsg string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	// gRPC status code and ErrorInfo reason of the failure.
+	Code   int32  `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *WaitProcessResponse) Reset() {
-	*x = WaitProcessResponse{}
+func (x *SignalResult) Reset() {
+	*x = SignalResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[3]
+		mi := &file_goproc_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WaitProcessResponse) String() string {
+func (x *SignalResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WaitProcessResponse) ProtoMessage() {}
+func (*SignalResult) ProtoMessage() {}
 
-func (x *WaitProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[3]
+func (x *SignalResult) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,57 +1932,74 @@ func (x *WaitProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WaitProcessResponse.ProtoReflect.Descriptor instead.
-func (*WaitProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use SignalResult.ProtoReflect.Descriptor instead.
+func (*SignalResult) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *WaitProcessResponse) GetOk() bool {
+func (x *SignalResult) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *SignalResult) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *WaitProcessResponse) GetExitCode() int32 {
+func (x *SignalResult) GetErrorMsg() string {
 	if x != nil {
-		return x.ExitCode
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *SignalResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
 	}
 	return 0
 }
 
-func (x *WaitProcessResponse) GetErrorMsg() string {
+func (x *SignalResult) GetReason() string {
 	if x != nil {
-		return x.ErrorMsg
+		return x.Reason
 	}
 	return ""
 }
 
-type KillProcessRequest struct {
+type SignalMatchingResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	// One result per matching process, in order of start time.
+	Results []*SignalResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *KillProcessRequest) Reset() {
-	*x = KillProcessRequest{}
+func (x *SignalMatchingResponse) Reset() {
+	*x = SignalMatchingResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[4]
+		mi := &file_goproc_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *KillProcessRequest) String() string {
+func (x *SignalMatchingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KillProcessRequest) ProtoMessage() {}
+func (*SignalMatchingResponse) ProtoMessage() {}
 
-func (x *KillProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[4]
+func (x *SignalMatchingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,44 +2010,59 @@ func (x *KillProcessRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KillProcessRequest.ProtoReflect.Descriptor instead.
-func (*KillProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SignalMatchingResponse.ProtoReflect.Descriptor instead.
+func (*SignalMatchingResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *KillProcessRequest) GetPid() int32 {
+func (x *SignalMatchingResponse) GetOk() bool {
 	if x != nil {
-		return x.Pid
+		return x.Ok
 	}
-	return 0
+	return false
 }
 
-type KillProcessResponse struct {
+func (x *SignalMatchingResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *SignalMatchingResponse) GetResults() []*SignalResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// WaitAll waits until every process matching label_selector, running or not,
+// has exited.
+type WaitAllRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 }
 
-func (x *KillProcessResponse) Reset() {
-	*x = KillProcessResponse{}
+func (x *WaitAllRequest) Reset() {
+	*x = WaitAllRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[5]
+		mi := &file_goproc_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *KillProcessResponse) String() string {
+func (x *WaitAllRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KillProcessResponse) ProtoMessage() {}
+func (*WaitAllRequest) ProtoMessage() {}
 
-func (x *KillProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[5]
+func (x *WaitAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -355,51 +2073,45 @@ func (x *KillProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KillProcessResponse.ProtoReflect.Descriptor instead.
-func (*KillProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *KillProcessResponse) GetOk() bool {
-	if x != nil {
-		return x.Ok
-	}
-	return false
+// Deprecated: Use WaitAllRequest.ProtoReflect.Descriptor instead.
+func (*WaitAllRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *KillProcessResponse) GetErrorMsg() string {
+func (x *WaitAllRequest) GetLabelSelector() string {
 	if x != nil {
-		return x.ErrorMsg
+		return x.LabelSelector
 	}
 	return ""
 }
 
-type SignalProcessRequest struct {
+type ProcessExit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid    int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
-	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	Pid            int32   `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	ExitCode       int32   `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	StageExitCodes []int32 `protobuf:"varint,3,rep,packed,name=stage_exit_codes,json=stageExitCodes,proto3" json:"stage_exit_codes,omitempty"`
 }
 
-func (x *SignalProcessRequest) Reset() {
-	*x = SignalProcessRequest{}
+func (x *ProcessExit) Reset() {
+	*x = ProcessExit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[6]
+		mi := &file_goproc_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignalProcessRequest) String() string {
+func (x *ProcessExit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignalProcessRequest) ProtoMessage() {}
+func (*ProcessExit) ProtoMessage() {}
 
-func (x *SignalProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[6]
+func (x *ProcessExit) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,51 +2122,60 @@ func (x *SignalProcessRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignalProcessRequest.ProtoReflect.Descriptor instead.
-func (*SignalProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ProcessExit.ProtoReflect.Descriptor instead.
+func (*ProcessExit) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *SignalProcessRequest) GetPid() int32 {
+func (x *ProcessExit) GetPid() int32 {
 	if x != nil {
 		return x.Pid
 	}
 	return 0
 }
 
-func (x *SignalProcessRequest) GetSignal() int32 {
+func (x *ProcessExit) GetExitCode() int32 {
 	if x != nil {
-		return x.Signal
+		return x.ExitCode
 	}
 	return 0
 }
 
-type SignalProcessResponse struct {
+func (x *ProcessExit) GetStageExitCodes() []int32 {
+	if x != nil {
+		return x.StageExitCodes
+	}
+	return nil
+}
+
+type WaitAllResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	// One result per matching process, in order of start time.
+	Results []*ProcessExit `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *SignalProcessResponse) Reset() {
-	*x = SignalProcessResponse{}
+func (x *WaitAllResponse) Reset() {
+	*x = WaitAllResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[7]
+		mi := &file_goproc_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignalProcessResponse) String() string {
+func (x *WaitAllResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignalProcessResponse) ProtoMessage() {}
+func (*WaitAllResponse) ProtoMessage() {}
 
-func (x *SignalProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[7]
+func (x *WaitAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,50 +2186,73 @@ func (x *SignalProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignalProcessResponse.ProtoReflect.Descriptor instead.
-func (*SignalProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use WaitAllResponse.ProtoReflect.Descriptor instead.
+func (*WaitAllResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *SignalProcessResponse) GetOk() bool {
+func (x *WaitAllResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *SignalProcessResponse) GetErrorMsg() string {
+func (x *WaitAllResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type StatusProcessRequest struct {
+func (x *WaitAllResponse) GetResults() []*ProcessExit {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ProcessInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Pid int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Cmd string `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	Cwd string `protobuf:"bytes,3,opt,name=cwd,proto3" json:"cwd,omitempty"`
+	// Entries of a request's env are reported as given, before expansion.
+	// Values read from env_files or matching the server's redaction rules or
+	// sensitive_env are replaced by "[redacted]".
+	Env      []string     `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`
+	Running  bool         `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	ExitCode int32        `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Stdin    bool         `protobuf:"varint,7,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	Tty      bool         `protobuf:"varint,8,opt,name=tty,proto3" json:"tty,omitempty"`
+	State    ProcessState `protobuf:"varint,9,opt,name=state,proto3,enum=goproc.ProcessState" json:"state,omitempty"`
+	// The stages of a pipeline, in order.
+	Stages            []*PipelineStageInfo `protobuf:"bytes,10,rep,name=stages,proto3" json:"stages,omitempty"`
+	Name              string               `protobuf:"bytes,11,opt,name=name,proto3" json:"name,omitempty"`
+	Labels            map[string]string    `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	StartedAtUnixNano int64                `protobuf:"varint,13,opt,name=started_at_unix_nano,json=startedAtUnixNano,proto3" json:"started_at_unix_nano,omitempty"`
 }
 
-func (x *StatusProcessRequest) Reset() {
-	*x = StatusProcessRequest{}
+func (x *ProcessInfo) Reset() {
+	*x = ProcessInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[8]
+		mi := &file_goproc_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusProcessRequest) String() string {
+func (x *ProcessInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusProcessRequest) ProtoMessage() {}
+func (*ProcessInfo) ProtoMessage() {}
 
-func (x *StatusProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[8]
+func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,109 +2260,133 @@ func (x *StatusProcessRequest) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessInfo.ProtoReflect.Descriptor instead.
+func (*ProcessInfo) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ProcessInfo) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessInfo) GetCmd() string {
+	if x != nil {
+		return x.Cmd
+	}
+	return ""
+}
+
+func (x *ProcessInfo) GetCwd() string {
+	if x != nil {
+		return x.Cwd
+	}
+	return ""
+}
+
+func (x *ProcessInfo) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
 }
 
-// Deprecated: Use StatusProcessRequest.ProtoReflect.Descriptor instead.
-func (*StatusProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{8}
+func (x *ProcessInfo) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
 }
 
-func (x *StatusProcessRequest) GetPid() int32 {
+func (x *ProcessInfo) GetExitCode() int32 {
 	if x != nil {
-		return x.Pid
+		return x.ExitCode
 	}
 	return 0
 }
 
-type StatusProcessResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Ok       bool         `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Process  *ProcessInfo `protobuf:"bytes,2,opt,name=process,proto3" json:"process,omitempty"`
-	ErrorMsg string       `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
-}
-
-func (x *StatusProcessResponse) Reset() {
-	*x = StatusProcessResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ProcessInfo) GetStdin() bool {
+	if x != nil {
+		return x.Stdin
 	}
+	return false
 }
 
-func (x *StatusProcessResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ProcessInfo) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
 }
 
-func (*StatusProcessResponse) ProtoMessage() {}
-
-func (x *StatusProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ProcessInfo) GetState() ProcessState {
+	if x != nil {
+		return x.State
 	}
-	return mi.MessageOf(x)
+	return ProcessState_PROCESS_STATE_UNSPECIFIED
 }
 
-// Deprecated: Use StatusProcessResponse.ProtoReflect.Descriptor instead.
-func (*StatusProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{9}
+func (x *ProcessInfo) GetStages() []*PipelineStageInfo {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
 }
 
-func (x *StatusProcessResponse) GetOk() bool {
+func (x *ProcessInfo) GetName() string {
 	if x != nil {
-		return x.Ok
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *StatusProcessResponse) GetProcess() *ProcessInfo {
+func (x *ProcessInfo) GetLabels() map[string]string {
 	if x != nil {
-		return x.Process
+		return x.Labels
 	}
 	return nil
 }
 
-func (x *StatusProcessResponse) GetErrorMsg() string {
+func (x *ProcessInfo) GetStartedAtUnixNano() int64 {
 	if x != nil {
-		return x.ErrorMsg
+		return x.StartedAtUnixNano
 	}
-	return ""
+	return 0
 }
 
-type StdoutProcessRequest struct {
+type PipelineStageInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Pid int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Cmd string `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	// Unset while the stage is running.
+	ExitCode *int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
 }
 
-func (x *StdoutProcessRequest) Reset() {
-	*x = StdoutProcessRequest{}
+func (x *PipelineStageInfo) Reset() {
+	*x = PipelineStageInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[10]
+		mi := &file_goproc_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StdoutProcessRequest) String() string {
+func (x *PipelineStageInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StdoutProcessRequest) ProtoMessage() {}
+func (*PipelineStageInfo) ProtoMessage() {}
 
-func (x *StdoutProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[10]
+func (x *PipelineStageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,45 +2397,61 @@ func (x *StdoutProcessRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StdoutProcessRequest.ProtoReflect.Descriptor instead.
-func (*StdoutProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use PipelineStageInfo.ProtoReflect.Descriptor instead.
+func (*PipelineStageInfo) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *StdoutProcessRequest) GetPid() int32 {
+func (x *PipelineStageInfo) GetPid() int32 {
 	if x != nil {
 		return x.Pid
 	}
 	return 0
 }
 
-type StdoutProcessResponse struct {
+func (x *PipelineStageInfo) GetCmd() string {
+	if x != nil {
+		return x.Cmd
+	}
+	return ""
+}
+
+func (x *PipelineStageInfo) GetExitCode() int32 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
+	}
+	return 0
+}
+
+type ListProcessesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
-	Stdout   string `protobuf:"bytes,3,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Ok        bool           `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Processes []*ProcessInfo `protobuf:"bytes,2,rep,name=processes,proto3" json:"processes,omitempty"`
+	ErrorMsg  string         `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	// Set when there are more processes to list.
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *StdoutProcessResponse) Reset() {
-	*x = StdoutProcessResponse{}
+func (x *ListProcessesResponse) Reset() {
+	*x = ListProcessesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[11]
+		mi := &file_goproc_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StdoutProcessResponse) String() string {
+func (x *ListProcessesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StdoutProcessResponse) ProtoMessage() {}
+func (*ListProcessesResponse) ProtoMessage() {}
 
-func (x *StdoutProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[11]
+func (x *ListProcessesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,57 +2462,69 @@ func (x *StdoutProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StdoutProcessResponse.ProtoReflect.Descriptor instead.
-func (*StdoutProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListProcessesResponse.ProtoReflect.Descriptor instead.
+func (*ListProcessesResponse) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *StdoutProcessResponse) GetOk() bool {
+func (x *ListProcessesResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *StdoutProcessResponse) GetErrorMsg() string {
+func (x *ListProcessesResponse) GetProcesses() []*ProcessInfo {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *ListProcessesResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-func (x *StdoutProcessResponse) GetStdout() string {
+func (x *ListProcessesResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Stdout
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type StderrProcessRequest struct {
+type WatchEventsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Only send events for these pids. Empty means all processes.
+	Pids []int32 `protobuf:"varint,1,rep,packed,name=pids,proto3" json:"pids,omitempty"`
+	// Only send events of these types. Empty means all types.
+	Types []ProcessEventType `protobuf:"varint,2,rep,packed,name=types,proto3,enum=goproc.ProcessEventType" json:"types,omitempty"`
+	// Resume after the event carrying this token instead of starting from now.
+	ResumeToken string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
-func (x *StderrProcessRequest) Reset() {
-	*x = StderrProcessRequest{}
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[12]
+		mi := &file_goproc_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StderrProcessRequest) String() string {
+func (x *WatchEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StderrProcessRequest) ProtoMessage() {}
+func (*WatchEventsRequest) ProtoMessage() {}
 
-func (x *StderrProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[12]
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,45 +2535,62 @@ func (x *StderrProcessRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StderrProcessRequest.ProtoReflect.Descriptor instead.
-func (*StderrProcessRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *StderrProcessRequest) GetPid() int32 {
+func (x *WatchEventsRequest) GetPids() []int32 {
 	if x != nil {
-		return x.Pid
+		return x.Pids
 	}
-	return 0
+	return nil
 }
 
-type StderrProcessResponse struct {
+func (x *WatchEventsRequest) GetTypes() []ProcessEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type ProcessEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
-	Stderr   string `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Type         ProcessEventType `protobuf:"varint,1,opt,name=type,proto3,enum=goproc.ProcessEventType" json:"type,omitempty"`
+	Process      *ProcessInfo     `protobuf:"bytes,2,opt,name=process,proto3" json:"process,omitempty"`
+	ResumeToken  string           `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	TimeUnixNano int64            `protobuf:"varint,4,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// Signal number delivered, set for PROCESS_EVENT_SIGNALED.
+	Signal int32 `protobuf:"varint,5,opt,name=signal,proto3" json:"signal,omitempty"`
 }
 
-func (x *StderrProcessResponse) Reset() {
-	*x = StderrProcessResponse{}
+func (x *ProcessEvent) Reset() {
+	*x = ProcessEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[13]
+		mi := &file_goproc_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StderrProcessResponse) String() string {
+func (x *ProcessEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StderrProcessResponse) ProtoMessage() {}
+func (*ProcessEvent) ProtoMessage() {}
 
-func (x *StderrProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[13]
+func (x *ProcessEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,55 +2601,74 @@ func (x *StderrProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StderrProcessResponse.ProtoReflect.Descriptor instead.
-func (*StderrProcessResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ProcessEvent.ProtoReflect.Descriptor instead.
+func (*ProcessEvent) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *StderrProcessResponse) GetOk() bool {
+func (x *ProcessEvent) GetType() ProcessEventType {
 	if x != nil {
-		return x.Ok
+		return x.Type
 	}
-	return false
+	return ProcessEventType_PROCESS_EVENT_UNSPECIFIED
 }
 
-func (x *StderrProcessResponse) GetErrorMsg() string {
+func (x *ProcessEvent) GetProcess() *ProcessInfo {
 	if x != nil {
-		return x.ErrorMsg
+		return x.Process
 	}
-	return ""
+	return nil
 }
 
-func (x *StderrProcessResponse) GetStderr() string {
+func (x *ProcessEvent) GetResumeToken() string {
 	if x != nil {
-		return x.Stderr
+		return x.ResumeToken
 	}
 	return ""
 }
 
-type ListProcessesRequest struct {
+func (x *ProcessEvent) GetTimeUnixNano() int64 {
+	if x != nil {
+		return x.TimeUnixNano
+	}
+	return 0
+}
+
+func (x *ProcessEvent) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+type StreamOutputRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Keep streaming new output until the process exits. Otherwise only the
+	// output buffered so far is sent.
+	Follow bool `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
 }
 
-func (x *ListProcessesRequest) Reset() {
-	*x = ListProcessesRequest{}
+func (x *StreamOutputRequest) Reset() {
+	*x = StreamOutputRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[14]
+		mi := &file_goproc_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListProcessesRequest) String() string {
+func (x *StreamOutputRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProcessesRequest) ProtoMessage() {}
+func (*StreamOutputRequest) ProtoMessage() {}
 
-func (x *ListProcessesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[14]
+func (x *StreamOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -847,41 +2679,58 @@ func (x *ListProcessesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProcessesRequest.ProtoReflect.Descriptor instead.
-func (*ListProcessesRequest) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use StreamOutputRequest.ProtoReflect.Descriptor instead.
+func (*StreamOutputRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{33}
 }
 
-type ProcessInfo struct {
+func (x *StreamOutputRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *StreamOutputRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+// Output already buffered is sent first without being consumed, so it is still
+// returned by Stdout and Stderr.
+type OutputChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pid      int32    `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
-	Cmd      string   `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
-	Cwd      string   `protobuf:"bytes,3,opt,name=cwd,proto3" json:"cwd,omitempty"`
-	Env      []string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`
-	Running  bool     `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
-	ExitCode int32    `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Stream OutputStream `protobuf:"varint,1,opt,name=stream,proto3,enum=goproc.OutputStream" json:"stream,omitempty"`
+	Data   []byte       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Set on the last chunk of a followed stream once the process has exited.
+	ExitCode *int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
+	// Bytes of this stream a follower missed before data: they were read with
+	// Stdout, Stderr or Logs before it caught up with them.
+	SkippedBytes uint64 `protobuf:"varint,4,opt,name=skipped_bytes,json=skippedBytes,proto3" json:"skipped_bytes,omitempty"`
 }
 
-func (x *ProcessInfo) Reset() {
-	*x = ProcessInfo{}
+func (x *OutputChunk) Reset() {
+	*x = OutputChunk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[15]
+		mi := &file_goproc_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProcessInfo) String() string {
+func (x *OutputChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessInfo) ProtoMessage() {}
+func (*OutputChunk) ProtoMessage() {}
 
-func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[15]
+func (x *OutputChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,80 +2741,70 @@ func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessInfo.ProtoReflect.Descriptor instead.
-func (*ProcessInfo) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *ProcessInfo) GetPid() int32 {
-	if x != nil {
-		return x.Pid
-	}
-	return 0
-}
-
-func (x *ProcessInfo) GetCmd() string {
-	if x != nil {
-		return x.Cmd
-	}
-	return ""
+// Deprecated: Use OutputChunk.ProtoReflect.Descriptor instead.
+func (*OutputChunk) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ProcessInfo) GetCwd() string {
+func (x *OutputChunk) GetStream() OutputStream {
 	if x != nil {
-		return x.Cwd
+		return x.Stream
 	}
-	return ""
+	return OutputStream_OUTPUT_STREAM_UNSPECIFIED
 }
 
-func (x *ProcessInfo) GetEnv() []string {
+func (x *OutputChunk) GetData() []byte {
 	if x != nil {
-		return x.Env
+		return x.Data
 	}
 	return nil
 }
 
-func (x *ProcessInfo) GetRunning() bool {
-	if x != nil {
-		return x.Running
+func (x *OutputChunk) GetExitCode() int32 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
 	}
-	return false
+	return 0
 }
 
-func (x *ProcessInfo) GetExitCode() int32 {
+func (x *OutputChunk) GetSkippedBytes() uint64 {
 	if x != nil {
-		return x.ExitCode
+		return x.SkippedBytes
 	}
 	return 0
 }
 
-type ListProcessesResponse struct {
+// The first message of an Attach stream names the process. Every message may
+// carry stdin data and a terminal resize.
+type AttachRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok        bool           `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Processes []*ProcessInfo `protobuf:"bytes,2,rep,name=processes,proto3" json:"processes,omitempty"`
-	ErrorMsg  string         `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	Pid   int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Stdin []byte `protobuf:"bytes,2,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// Close stdin after writing stdin. For a terminal this sends end-of-file.
+	CloseStdin bool          `protobuf:"varint,3,opt,name=close_stdin,json=closeStdin,proto3" json:"close_stdin,omitempty"`
+	Resize     *TerminalSize `protobuf:"bytes,4,opt,name=resize,proto3" json:"resize,omitempty"`
 }
 
-func (x *ListProcessesResponse) Reset() {
-	*x = ListProcessesResponse{}
+func (x *AttachRequest) Reset() {
+	*x = AttachRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goproc_proto_msgTypes[16]
+		mi := &file_goproc_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListProcessesResponse) String() string {
+func (x *AttachRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProcessesResponse) ProtoMessage() {}
+func (*AttachRequest) ProtoMessage() {}
 
-func (x *ListProcessesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_goproc_proto_msgTypes[16]
+func (x *AttachRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goproc_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -976,160 +2815,469 @@ func (x *ListProcessesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProcessesResponse.ProtoReflect.Descriptor instead.
-func (*ListProcessesResponse) Descriptor() ([]byte, []int) {
-	return file_goproc_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use AttachRequest.ProtoReflect.Descriptor instead.
+func (*AttachRequest) Descriptor() ([]byte, []int) {
+	return file_goproc_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ListProcessesResponse) GetOk() bool {
+func (x *AttachRequest) GetPid() int32 {
 	if x != nil {
-		return x.Ok
+		return x.Pid
 	}
-	return false
+	return 0
 }
 
-func (x *ListProcessesResponse) GetProcesses() []*ProcessInfo {
+func (x *AttachRequest) GetStdin() []byte {
 	if x != nil {
-		return x.Processes
+		return x.Stdin
 	}
 	return nil
 }
 
-func (x *ListProcessesResponse) GetErrorMsg() string {
+func (x *AttachRequest) GetCloseStdin() bool {
 	if x != nil {
-		return x.ErrorMsg
+		return x.CloseStdin
 	}
-	return ""
+	return false
+}
+
+func (x *AttachRequest) GetResize() *TerminalSize {
+	if x != nil {
+		return x.Resize
+	}
+	return nil
 }
 
 var File_goproc_proto protoreflect.FileDescriptor
 
 var file_goproc_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x22, 0x6e, 0x0a, 0x12, 0x45, 0x78, 0x65, 0x63, 0x50, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
-	0x12, 0x10, 0x0a, 0x03, 0x63, 0x77, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63,
-	0x77, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x03, 0x65, 0x6e, 0x76, 0x12, 0x17, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a,
-	0x05, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x22, 0x84, 0x01, 0x0a, 0x13, 0x45, 0x78, 0x65, 0x63, 0x50,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x22, 0xea, 0x06, 0x0a, 0x12, 0x45, 0x78, 0x65, 0x63, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x77, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x63, 0x77, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x17, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73,
+	0x74, 0x64, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x39, 0x0a, 0x0d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x52, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d,
+	0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67,
+	0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x08,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f,
+	0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x73, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45, 0x6e,
+	0x76, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x65, 0x6e, 0x76, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x76, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c,
+	0x69, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x5f, 0x65, 0x6e,
+	0x76, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x45,
+	0x6e, 0x76, 0x12, 0x45, 0x0a, 0x09, 0x65, 0x6e, 0x76, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45,
+	0x78, 0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x45, 0x6e, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x65, 0x6e, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x76, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x14, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x45, 0x6e, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x77,
+	0x61, 0x69, 0x74, 0x22, 0x59, 0x0a, 0x11, 0x45, 0x78, 0x65, 0x63, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x65, 0x78, 0x65, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x04, 0x65, 0x78, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x22, 0x62,
+	0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2c,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x67,
+	0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x08, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x28, 0x0a,
+	0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52,
+	0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x22, 0x4d, 0x0a, 0x0d, 0x50, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x28, 0x0a, 0x06,
+	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67,
+	0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x06,
+	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x22, 0x36, 0x0a, 0x0c, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x13, 0x45,
+	0x78, 0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02,
+	0x6f, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x70, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
+	0x67, 0x12, 0x20, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x65, 0x78, 0x69,
+	0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0e, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x26, 0x0a, 0x12, 0x57,
+	0x61, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x70, 0x69, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x13, 0x57, 0x61, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65,
+	0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x65,
+	0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x0e, 0x73, 0x74, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x22,
+	0x26, 0x0a, 0x12, 0x4b, 0x69, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x42, 0x0a, 0x13, 0x4b, 0x69, 0x6c, 0x6c, 0x50,
 	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x10,
-	0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64,
-	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x20, 0x0a,
-	0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x48, 0x00, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42,
-	0x0c, 0x0a, 0x0a, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x26, 0x0a,
-	0x12, 0x57, 0x61, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b,
+	0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x40, 0x0a, 0x14, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x5f, 0x0a, 0x13, 0x57, 0x61, 0x69, 0x74, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09,
-	0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x26, 0x0a, 0x12, 0x4b, 0x69, 0x6c, 0x6c, 0x50, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x42,
-	0x0a, 0x13, 0x4b, 0x69, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
+	0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x22, 0x44, 0x0a,
+	0x15, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x4d, 0x73, 0x67, 0x22, 0x28, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x73, 0x0a,
+	0x15, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x2d, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
+	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
+	0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x73, 0x67, 0x22, 0x28, 0x0a, 0x14, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x15,
+	0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
 	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
-	0x73, 0x67, 0x22, 0x40, 0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x6c, 0x22, 0x44, 0x0a, 0x15, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72,
+	0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x22, 0x28, 0x0a, 0x14, 0x53, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x70, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x15, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x72,
 	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
 	0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a,
 	0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x28, 0x0a, 0x14, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x03, 0x70, 0x69, 0x64, 0x22, 0x73, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x2d, 0x0a,
-	0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65,
+	0x72, 0x72, 0x22, 0x97, 0x02, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x2f, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3c, 0x0a, 0x13,
+	0x4b, 0x69, 0x6c, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x56, 0x0a, 0x15, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x6c, 0x22, 0x79, 0x0a, 0x0c, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x70, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x75, 0x0a,
+	0x16, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x73, 0x67, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x22, 0x37, 0x0a, 0x0e, 0x57, 0x61, 0x69, 0x74, 0x41, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x66, 0x0a,
+	0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x74,
+	0x43, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x6d, 0x0a, 0x0f, 0x57, 0x61, 0x69, 0x74, 0x41, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x69, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x22, 0xcc, 0x03, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x77, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x77, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e,
+	0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
+	0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x37,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
 	0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x28, 0x0a, 0x14, 0x53, 0x74, 0x64,
-	0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x6e, 0x66, 0x6f, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x67, 0x0a, 0x11, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53,
+	0x74, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x20, 0x0a, 0x09,
+	0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0c,
+	0x0a, 0x0a, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x9f, 0x01, 0x0a,
+	0x15, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x70, 0x72,
+	0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x7b,
+	0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x04, 0x70, 0x69, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
+	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xcc, 0x01, 0x0a, 0x0c,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f,
+	0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0e,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61,
+	0x6e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x22, 0x3f, 0x0a, 0x13, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x70, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x15, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
-	0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75,
-	0x74, 0x22, 0x28, 0x0a, 0x14, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x15, 0x53,
-	0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
-	0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73,
-	0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x8c, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x70, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x77, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x63, 0x77, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e,
-	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e,
-	0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65,
-	0x22, 0x77, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67,
-	0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x32, 0xc5, 0x04, 0x0a, 0x06, 0x47, 0x6f,
-	0x50, 0x72, 0x6f, 0x63, 0x12, 0x41, 0x0a, 0x04, 0x45, 0x78, 0x65, 0x63, 0x12, 0x1a, 0x2e, 0x67,
-	0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
-	0x63, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x04, 0x57, 0x61, 0x69, 0x74, 0x12,
-	0x1a, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6f,
-	0x70, 0x72, 0x6f, 0x63, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x04, 0x4b, 0x69,
-	0x6c, 0x6c, 0x12, 0x1a, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4b, 0x69, 0x6c, 0x6c,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
-	0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4b, 0x69, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a,
-	0x06, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
-	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x72,
+	0x70, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x22, 0xa4, 0x01, 0x0a, 0x0b,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2c, 0x0a, 0x06, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a,
+	0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x00, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x2c, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x52, 0x06, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x2a, 0x7c, 0x0a, 0x07, 0x45,
+	0x6e, 0x76, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x4e, 0x56, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x14, 0x0a, 0x10, 0x45, 0x4e, 0x56, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x50,
+	0x4c, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x4e, 0x56, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x49, 0x4e, 0x48, 0x45, 0x52, 0x49, 0x54, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x4f,
+	0x56, 0x45, 0x52, 0x52, 0x49, 0x44, 0x45, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x4e, 0x56,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x48, 0x45, 0x52, 0x49, 0x54, 0x5f, 0x41, 0x4c,
+	0x4c, 0x4f, 0x57, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x03, 0x2a, 0x97, 0x01, 0x0a, 0x10, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d,
+	0x0a, 0x19, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a,
+	0x14, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x42,
+	0x55, 0x46, 0x46, 0x45, 0x52, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x55, 0x54, 0x50, 0x55,
+	0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x02, 0x12,
+	0x19, 0x0a, 0x15, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54,
+	0x5f, 0x44, 0x49, 0x53, 0x43, 0x41, 0x52, 0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x55,
+	0x54, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x4d, 0x45, 0x52, 0x47,
+	0x45, 0x10, 0x04, 0x2a, 0x7a, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x19, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4f, 0x52,
+	0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x5f, 0x50, 0x49, 0x44, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x43, 0x45,
+	0x53, 0x53, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x03, 0x2a,
+	0x7a, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x1d, 0x0a, 0x19, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19,
+	0x0a, 0x15, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x4f,
+	0x43, 0x45, 0x53, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x58, 0x49, 0x54, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x2a, 0xe0, 0x01, 0x0a, 0x10,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1d, 0x0a, 0x19, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x19, 0x0a, 0x15, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52,
+	0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x45, 0x58, 0x49, 0x54,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x4c, 0x45, 0x44, 0x10, 0x03,
+	0x12, 0x19, 0x0a, 0x15, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x06, 0x22, 0x04, 0x08, 0x04, 0x10,
+	0x04, 0x22, 0x04, 0x08, 0x05, 0x10, 0x05, 0x2a, 0x17, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53,
+	0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44,
+	0x2a, 0x1c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x2a, 0x61,
+	0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1d,
+	0x0a, 0x19, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a,
+	0x14, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f, 0x53,
+	0x54, 0x44, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x55, 0x54, 0x50, 0x55,
+	0x54, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f, 0x53, 0x54, 0x44, 0x45, 0x52, 0x52, 0x10,
+	0x02, 0x32, 0xb6, 0x08, 0x0a, 0x06, 0x47, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x12, 0x41, 0x0a, 0x04,
+	0x45, 0x78, 0x65, 0x63, 0x12, 0x1a, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45, 0x78,
+	0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x50, 0x72,
 	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x47, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72,
-	0x6f, 0x63, 0x2e, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
-	0x2e, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x65,
-	0x72, 0x72, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x64, 0x65,
-	0x72, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x65, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x62, 0x65, 0x61, 0x6d, 0x2d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2f, 0x67, 0x6f, 0x70, 0x72, 0x6f,
-	0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x41, 0x0a, 0x04, 0x57, 0x61, 0x69, 0x74, 0x12, 0x1a, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
+	0x2e, 0x57, 0x61, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x57, 0x61, 0x69,
+	0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x04, 0x4b, 0x69, 0x6c, 0x6c, 0x12, 0x1a, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x4b, 0x69, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e,
+	0x4b, 0x69, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12,
+	0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x47, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x63, 0x2e, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f,
+	0x63, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44,
+	0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1b,
+	0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x6f,
+	0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x06, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x12, 0x15,
+	0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
+	0x12, 0x48, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x19,
+	0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x70, 0x72,
+	0x6f, 0x63, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4d, 0x0a, 0x0c, 0x4b, 0x69,
+	0x6c, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x4b, 0x69, 0x6c, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x2e, 0x67, 0x6f,
+	0x70, 0x72, 0x6f, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x6f, 0x70,
+	0x72, 0x6f, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07,
+	0x57, 0x61, 0x69, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63,
+	0x2e, 0x57, 0x61, 0x69, 0x74, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x41, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x61, 0x6d, 0x2d, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x2f, 0x67, 0x6f, 0x70, 0x72, 0x6f, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1144,50 +3292,118 @@ func file_goproc_proto_rawDescGZIP() []byte {
 	return file_goproc_proto_rawDescData
 }
 
-var file_goproc_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_goproc_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_goproc_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_goproc_proto_goTypes = []interface{}{
-	(*ExecProcessRequest)(nil),    // 0: goproc.ExecProcessRequest
-	(*ExecProcessResponse)(nil),   // 1: goproc.ExecProcessResponse
-	(*WaitProcessRequest)(nil),    // 2: goproc.WaitProcessRequest
-	(*WaitProcessResponse)(nil),   // 3: goproc.WaitProcessResponse
-	(*KillProcessRequest)(nil),    // 4: goproc.KillProcessRequest
-	(*KillProcessResponse)(nil),   // 5: goproc.KillProcessResponse
-	(*SignalProcessRequest)(nil),  // 6: goproc.SignalProcessRequest
-	(*SignalProcessResponse)(nil), // 7: goproc.SignalProcessResponse
-	(*StatusProcessRequest)(nil),  // 8: goproc.StatusProcessRequest
-	(*StatusProcessResponse)(nil), // 9: goproc.StatusProcessResponse
-	(*StdoutProcessRequest)(nil),  // 10: goproc.StdoutProcessRequest
-	(*StdoutProcessResponse)(nil), // 11: goproc.StdoutProcessResponse
-	(*StderrProcessRequest)(nil),  // 12: goproc.StderrProcessRequest
-	(*StderrProcessResponse)(nil), // 13: goproc.StderrProcessResponse
-	(*ListProcessesRequest)(nil),  // 14: goproc.ListProcessesRequest
-	(*ProcessInfo)(nil),           // 15: goproc.ProcessInfo
-	(*ListProcessesResponse)(nil), // 16: goproc.ListProcessesResponse
+	(EnvMode)(0),                   // 0: goproc.EnvMode
+	(OutputTargetType)(0),          // 1: goproc.OutputTargetType
+	(ProcessOrder)(0),              // 2: goproc.ProcessOrder
+	(ProcessState)(0),              // 3: goproc.ProcessState
+	(ProcessEventType)(0),          // 4: goproc.ProcessEventType
+	(OutputStream)(0),              // 5: goproc.OutputStream
+	(*ExecProcessRequest)(nil),     // 6: goproc.ExecProcessRequest
+	(*ExecUploadRequest)(nil),      // 7: goproc.ExecUploadRequest
+	(*OutputTarget)(nil),           // 8: goproc.OutputTarget
+	(*Pipeline)(nil),               // 9: goproc.Pipeline
+	(*PipelineStage)(nil),          // 10: goproc.PipelineStage
+	(*Redirect)(nil),               // 11: goproc.Redirect
+	(*TerminalSize)(nil),           // 12: goproc.TerminalSize
+	(*ExecProcessResponse)(nil),    // 13: goproc.ExecProcessResponse
+	(*WaitProcessRequest)(nil),     // 14: goproc.WaitProcessRequest
+	(*WaitProcessResponse)(nil),    // 15: goproc.WaitProcessResponse
+	(*KillProcessRequest)(nil),     // 16: goproc.KillProcessRequest
+	(*KillProcessResponse)(nil),    // 17: goproc.KillProcessResponse
+	(*SignalProcessRequest)(nil),   // 18: goproc.SignalProcessRequest
+	(*SignalProcessResponse)(nil),  // 19: goproc.SignalProcessResponse
+	(*StatusProcessRequest)(nil),   // 20: goproc.StatusProcessRequest
+	(*StatusProcessResponse)(nil),  // 21: goproc.StatusProcessResponse
+	(*StdoutProcessRequest)(nil),   // 22: goproc.StdoutProcessRequest
+	(*StdoutProcessResponse)(nil),  // 23: goproc.StdoutProcessResponse
+	(*StderrProcessRequest)(nil),   // 24: goproc.StderrProcessRequest
+	(*StderrProcessResponse)(nil),  // 25: goproc.StderrProcessResponse
+	(*ListProcessesRequest)(nil),   // 26: goproc.ListProcessesRequest
+	(*KillMatchingRequest)(nil),    // 27: goproc.KillMatchingRequest
+	(*SignalMatchingRequest)(nil),  // 28: goproc.SignalMatchingRequest
+	(*SignalResult)(nil),           // 29: goproc.SignalResult
+	(*SignalMatchingResponse)(nil), // 30: goproc.SignalMatchingResponse
+	(*WaitAllRequest)(nil),         // 31: goproc.WaitAllRequest
+	(*ProcessExit)(nil),            // 32: goproc.ProcessExit
+	(*WaitAllResponse)(nil),        // 33: goproc.WaitAllResponse
+	(*ProcessInfo)(nil),            // 34: goproc.ProcessInfo
+	(*PipelineStageInfo)(nil),      // 35: goproc.PipelineStageInfo
+	(*ListProcessesResponse)(nil),  // 36: goproc.ListProcessesResponse
+	(*WatchEventsRequest)(nil),     // 37: goproc.WatchEventsRequest
+	(*ProcessEvent)(nil),           // 38: goproc.ProcessEvent
+	(*StreamOutputRequest)(nil),    // 39: goproc.StreamOutputRequest
+	(*OutputChunk)(nil),            // 40: goproc.OutputChunk
+	(*AttachRequest)(nil),          // 41: goproc.AttachRequest
+	nil,                            // 42: goproc.ExecProcessRequest.EnvFilesEntry
+	nil,                            // 43: goproc.ExecProcessRequest.LabelsEntry
+	nil,                            // 44: goproc.ProcessInfo.LabelsEntry
 }
 var file_goproc_proto_depIdxs = []int32{
-	15, // 0: goproc.StatusProcessResponse.process:type_name -> goproc.ProcessInfo
-	15, // 1: goproc.ListProcessesResponse.processes:type_name -> goproc.ProcessInfo
-	0,  // 2: goproc.GoProc.Exec:input_type -> goproc.ExecProcessRequest
-	2,  // 3: goproc.GoProc.Wait:input_type -> goproc.WaitProcessRequest
-	4,  // 4: goproc.GoProc.Kill:input_type -> goproc.KillProcessRequest
-	6,  // 5: goproc.GoProc.Signal:input_type -> goproc.SignalProcessRequest
-	8,  // 6: goproc.GoProc.Status:input_type -> goproc.StatusProcessRequest
-	10, // 7: goproc.GoProc.Stdout:input_type -> goproc.StdoutProcessRequest
-	12, // 8: goproc.GoProc.Stderr:input_type -> goproc.StderrProcessRequest
-	14, // 9: goproc.GoProc.ListProcesses:input_type -> goproc.ListProcessesRequest
-	1,  // 10: goproc.GoProc.Exec:output_type -> goproc.ExecProcessResponse
-	3,  // 11: goproc.GoProc.Wait:output_type -> goproc.WaitProcessResponse
-	5,  // 12: goproc.GoProc.Kill:output_type -> goproc.KillProcessResponse
-	7,  // 13: goproc.GoProc.Signal:output_type -> goproc.SignalProcessResponse
-	9,  // 14: goproc.GoProc.Status:output_type -> goproc.StatusProcessResponse
-	11, // 15: goproc.GoProc.Stdout:output_type -> goproc.StdoutProcessResponse
-	13, // 16: goproc.GoProc.Stderr:output_type -> goproc.StderrProcessResponse
-	16, // 17: goproc.GoProc.ListProcesses:output_type -> goproc.ListProcessesResponse
-	10, // [10:18] is the sub-list for method output_type
-	2,  // [2:10] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	12, // 0: goproc.ExecProcessRequest.terminal_size:type_name -> goproc.TerminalSize
+	9,  // 1: goproc.ExecProcessRequest.pipeline:type_name -> goproc.Pipeline
+	8,  // 2: goproc.ExecProcessRequest.stdout:type_name -> goproc.OutputTarget
+	8,  // 3: goproc.ExecProcessRequest.stderr:type_name -> goproc.OutputTarget
+	0,  // 4: goproc.ExecProcessRequest.env_mode:type_name -> goproc.EnvMode
+	42, // 5: goproc.ExecProcessRequest.env_files:type_name -> goproc.ExecProcessRequest.EnvFilesEntry
+	43, // 6: goproc.ExecProcessRequest.labels:type_name -> goproc.ExecProcessRequest.LabelsEntry
+	6,  // 7: goproc.ExecUploadRequest.exec:type_name -> goproc.ExecProcessRequest
+	1,  // 8: goproc.OutputTarget.type:type_name -> goproc.OutputTargetType
+	11, // 9: goproc.OutputTarget.file:type_name -> goproc.Redirect
+	10, // 10: goproc.Pipeline.stages:type_name -> goproc.PipelineStage
+	11, // 11: goproc.Pipeline.stdout:type_name -> goproc.Redirect
+	11, // 12: goproc.PipelineStage.stderr:type_name -> goproc.Redirect
+	34, // 13: goproc.StatusProcessResponse.process:type_name -> goproc.ProcessInfo
+	3,  // 14: goproc.ListProcessesRequest.state:type_name -> goproc.ProcessState
+	2,  // 15: goproc.ListProcessesRequest.order_by:type_name -> goproc.ProcessOrder
+	29, // 16: goproc.SignalMatchingResponse.results:type_name -> goproc.SignalResult
+	32, // 17: goproc.WaitAllResponse.results:type_name -> goproc.ProcessExit
+	3,  // 18: goproc.ProcessInfo.state:type_name -> goproc.ProcessState
+	35, // 19: goproc.ProcessInfo.stages:type_name -> goproc.PipelineStageInfo
+	44, // 20: goproc.ProcessInfo.labels:type_name -> goproc.ProcessInfo.LabelsEntry
+	34, // 21: goproc.ListProcessesResponse.processes:type_name -> goproc.ProcessInfo
+	4,  // 22: goproc.WatchEventsRequest.types:type_name -> goproc.ProcessEventType
+	4,  // 23: goproc.ProcessEvent.type:type_name -> goproc.ProcessEventType
+	34, // 24: goproc.ProcessEvent.process:type_name -> goproc.ProcessInfo
+	5,  // 25: goproc.OutputChunk.stream:type_name -> goproc.OutputStream
+	12, // 26: goproc.AttachRequest.resize:type_name -> goproc.TerminalSize
+	6,  // 27: goproc.GoProc.Exec:input_type -> goproc.ExecProcessRequest
+	14, // 28: goproc.GoProc.Wait:input_type -> goproc.WaitProcessRequest
+	16, // 29: goproc.GoProc.Kill:input_type -> goproc.KillProcessRequest
+	18, // 30: goproc.GoProc.Signal:input_type -> goproc.SignalProcessRequest
+	20, // 31: goproc.GoProc.Status:input_type -> goproc.StatusProcessRequest
+	22, // 32: goproc.GoProc.Stdout:input_type -> goproc.StdoutProcessRequest
+	24, // 33: goproc.GoProc.Stderr:input_type -> goproc.StderrProcessRequest
+	26, // 34: goproc.GoProc.ListProcesses:input_type -> goproc.ListProcessesRequest
+	37, // 35: goproc.GoProc.WatchEvents:input_type -> goproc.WatchEventsRequest
+	39, // 36: goproc.GoProc.StreamOutput:input_type -> goproc.StreamOutputRequest
+	41, // 37: goproc.GoProc.Attach:input_type -> goproc.AttachRequest
+	7,  // 38: goproc.GoProc.ExecUpload:input_type -> goproc.ExecUploadRequest
+	27, // 39: goproc.GoProc.KillMatching:input_type -> goproc.KillMatchingRequest
+	28, // 40: goproc.GoProc.SignalMatching:input_type -> goproc.SignalMatchingRequest
+	31, // 41: goproc.GoProc.WaitAll:input_type -> goproc.WaitAllRequest
+	13, // 42: goproc.GoProc.Exec:output_type -> goproc.ExecProcessResponse
+	15, // 43: goproc.GoProc.Wait:output_type -> goproc.WaitProcessResponse
+	17, // 44: goproc.GoProc.Kill:output_type -> goproc.KillProcessResponse
+	19, // 45: goproc.GoProc.Signal:output_type -> goproc.SignalProcessResponse
+	21, // 46: goproc.GoProc.Status:output_type -> goproc.StatusProcessResponse
+	23, // 47: goproc.GoProc.Stdout:output_type -> goproc.StdoutProcessResponse
+	25, // 48: goproc.GoProc.Stderr:output_type -> goproc.StderrProcessResponse
+	36, // 49: goproc.GoProc.ListProcesses:output_type -> goproc.ListProcessesResponse
+	38, // 50: goproc.GoProc.WatchEvents:output_type -> goproc.ProcessEvent
+	40, // 51: goproc.GoProc.StreamOutput:output_type -> goproc.OutputChunk
+	40, // 52: goproc.GoProc.Attach:output_type -> goproc.OutputChunk
+	13, // 53: goproc.GoProc.ExecUpload:output_type -> goproc.ExecProcessResponse
+	30, // 54: goproc.GoProc.KillMatching:output_type -> goproc.SignalMatchingResponse
+	30, // 55: goproc.GoProc.SignalMatching:output_type -> goproc.SignalMatchingResponse
+	33, // 56: goproc.GoProc.WaitAll:output_type -> goproc.WaitAllResponse
+	42, // [42:57] is the sub-list for method output_type
+	27, // [27:42] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_goproc_proto_init() }
@@ -1209,7 +3425,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecProcessResponse); i {
+			switch v := v.(*ExecUploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1221,7 +3437,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WaitProcessRequest); i {
+			switch v := v.(*OutputTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1233,7 +3449,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WaitProcessResponse); i {
+			switch v := v.(*Pipeline); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1245,7 +3461,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KillProcessRequest); i {
+			switch v := v.(*PipelineStage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1257,7 +3473,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KillProcessResponse); i {
+			switch v := v.(*Redirect); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1269,7 +3485,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignalProcessRequest); i {
+			switch v := v.(*TerminalSize); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1281,7 +3497,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignalProcessResponse); i {
+			switch v := v.(*ExecProcessResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1293,7 +3509,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusProcessRequest); i {
+			switch v := v.(*WaitProcessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1305,7 +3521,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusProcessResponse); i {
+			switch v := v.(*WaitProcessResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1317,7 +3533,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StdoutProcessRequest); i {
+			switch v := v.(*KillProcessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1329,7 +3545,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StdoutProcessResponse); i {
+			switch v := v.(*KillProcessResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1341,7 +3557,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StderrProcessRequest); i {
+			switch v := v.(*SignalProcessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1353,7 +3569,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StderrProcessResponse); i {
+			switch v := v.(*SignalProcessResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1365,7 +3581,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListProcessesRequest); i {
+			switch v := v.(*StatusProcessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1377,7 +3593,7 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessInfo); i {
+			switch v := v.(*StatusProcessResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1389,6 +3605,174 @@ func file_goproc_proto_init() {
 			}
 		}
 		file_goproc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StdoutProcessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StdoutProcessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StderrProcessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StderrProcessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListProcessesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KillMatchingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalMatchingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalMatchingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaitAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessExit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaitAllResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PipelineStageInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListProcessesResponse); i {
 			case 0:
 				return &v.state
@@ -1400,21 +3784,84 @@ func file_goproc_proto_init() {
 				return nil
 			}
 		}
+		file_goproc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamOutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_goproc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_goproc_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_goproc_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_goproc_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_goproc_proto_msgTypes[29].OneofWrappers = []interface{}{}
+	file_goproc_proto_msgTypes[34].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_goproc_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      6,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_goproc_proto_goTypes,
 		DependencyIndexes: file_goproc_proto_depIdxs,
+		EnumInfos:         file_goproc_proto_enumTypes,
 		MessageInfos:      file_goproc_proto_msgTypes,
 	}.Build()
 	File_goproc_proto = out.File
@@ -19,14 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GoProc_Exec_FullMethodName          = "/goproc.GoProc/Exec"
-	GoProc_Wait_FullMethodName          = "/goproc.GoProc/Wait"
-	GoProc_Kill_FullMethodName          = "/goproc.GoProc/Kill"
-	GoProc_Signal_FullMethodName        = "/goproc.GoProc/Signal"
-	GoProc_Status_FullMethodName        = "/goproc.GoProc/Status"
-	GoProc_Stdout_FullMethodName        = "/goproc.GoProc/Stdout"
-	GoProc_Stderr_FullMethodName        = "/goproc.GoProc/Stderr"
-	GoProc_ListProcesses_FullMethodName = "/goproc.GoProc/ListProcesses"
+	GoProc_Exec_FullMethodName           = "/goproc.GoProc/Exec"
+	GoProc_Wait_FullMethodName           = "/goproc.GoProc/Wait"
+	GoProc_Kill_FullMethodName           = "/goproc.GoProc/Kill"
+	GoProc_Signal_FullMethodName         = "/goproc.GoProc/Signal"
+	GoProc_Status_FullMethodName         = "/goproc.GoProc/Status"
+	GoProc_Stdout_FullMethodName         = "/goproc.GoProc/Stdout"
+	GoProc_Stderr_FullMethodName         = "/goproc.GoProc/Stderr"
+	GoProc_ListProcesses_FullMethodName  = "/goproc.GoProc/ListProcesses"
+	GoProc_WatchEvents_FullMethodName    = "/goproc.GoProc/WatchEvents"
+	GoProc_StreamOutput_FullMethodName   = "/goproc.GoProc/StreamOutput"
+	GoProc_Attach_FullMethodName         = "/goproc.GoProc/Attach"
+	GoProc_ExecUpload_FullMethodName     = "/goproc.GoProc/ExecUpload"
+	GoProc_KillMatching_FullMethodName   = "/goproc.GoProc/KillMatching"
+	GoProc_SignalMatching_FullMethodName = "/goproc.GoProc/SignalMatching"
+	GoProc_WaitAll_FullMethodName        = "/goproc.GoProc/WaitAll"
 )
 
 // GoProcClient is the client API for GoProc service.
@@ -41,6 +48,13 @@ type GoProcClient interface {
 	Stdout(ctx context.Context, in *StdoutProcessRequest, opts ...grpc.CallOption) (*StdoutProcessResponse, error)
 	Stderr(ctx context.Context, in *StderrProcessRequest, opts ...grpc.CallOption) (*StderrProcessResponse, error)
 	ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error)
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (GoProc_WatchEventsClient, error)
+	StreamOutput(ctx context.Context, in *StreamOutputRequest, opts ...grpc.CallOption) (GoProc_StreamOutputClient, error)
+	Attach(ctx context.Context, opts ...grpc.CallOption) (GoProc_AttachClient, error)
+	ExecUpload(ctx context.Context, opts ...grpc.CallOption) (GoProc_ExecUploadClient, error)
+	KillMatching(ctx context.Context, in *KillMatchingRequest, opts ...grpc.CallOption) (*SignalMatchingResponse, error)
+	SignalMatching(ctx context.Context, in *SignalMatchingRequest, opts ...grpc.CallOption) (*SignalMatchingResponse, error)
+	WaitAll(ctx context.Context, in *WaitAllRequest, opts ...grpc.CallOption) (*WaitAllResponse, error)
 }
 
 type goProcClient struct {
@@ -123,6 +137,162 @@ func (c *goProcClient) ListProcesses(ctx context.Context, in *ListProcessesReque
 	return out, nil
 }
 
+func (c *goProcClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (GoProc_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoProc_ServiceDesc.Streams[0], GoProc_WatchEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goProcWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoProc_WatchEventsClient interface {
+	Recv() (*ProcessEvent, error)
+	grpc.ClientStream
+}
+
+type goProcWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *goProcWatchEventsClient) Recv() (*ProcessEvent, error) {
+	m := new(ProcessEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goProcClient) StreamOutput(ctx context.Context, in *StreamOutputRequest, opts ...grpc.CallOption) (GoProc_StreamOutputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoProc_ServiceDesc.Streams[1], GoProc_StreamOutput_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goProcStreamOutputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoProc_StreamOutputClient interface {
+	Recv() (*OutputChunk, error)
+	grpc.ClientStream
+}
+
+type goProcStreamOutputClient struct {
+	grpc.ClientStream
+}
+
+func (x *goProcStreamOutputClient) Recv() (*OutputChunk, error) {
+	m := new(OutputChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goProcClient) Attach(ctx context.Context, opts ...grpc.CallOption) (GoProc_AttachClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoProc_ServiceDesc.Streams[2], GoProc_Attach_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goProcAttachClient{stream}
+	return x, nil
+}
+
+type GoProc_AttachClient interface {
+	Send(*AttachRequest) error
+	Recv() (*OutputChunk, error)
+	grpc.ClientStream
+}
+
+type goProcAttachClient struct {
+	grpc.ClientStream
+}
+
+func (x *goProcAttachClient) Send(m *AttachRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *goProcAttachClient) Recv() (*OutputChunk, error) {
+	m := new(OutputChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goProcClient) ExecUpload(ctx context.Context, opts ...grpc.CallOption) (GoProc_ExecUploadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoProc_ServiceDesc.Streams[3], GoProc_ExecUpload_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goProcExecUploadClient{stream}
+	return x, nil
+}
+
+type GoProc_ExecUploadClient interface {
+	Send(*ExecUploadRequest) error
+	CloseAndRecv() (*ExecProcessResponse, error)
+	grpc.ClientStream
+}
+
+type goProcExecUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *goProcExecUploadClient) Send(m *ExecUploadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *goProcExecUploadClient) CloseAndRecv() (*ExecProcessResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ExecProcessResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goProcClient) KillMatching(ctx context.Context, in *KillMatchingRequest, opts ...grpc.CallOption) (*SignalMatchingResponse, error) {
+	out := new(SignalMatchingResponse)
+	err := c.cc.Invoke(ctx, GoProc_KillMatching_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goProcClient) SignalMatching(ctx context.Context, in *SignalMatchingRequest, opts ...grpc.CallOption) (*SignalMatchingResponse, error) {
+	out := new(SignalMatchingResponse)
+	err := c.cc.Invoke(ctx, GoProc_SignalMatching_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goProcClient) WaitAll(ctx context.Context, in *WaitAllRequest, opts ...grpc.CallOption) (*WaitAllResponse, error) {
+	out := new(WaitAllResponse)
+	err := c.cc.Invoke(ctx, GoProc_WaitAll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoProcServer is the server API for GoProc service.
 // All implementations must embed UnimplementedGoProcServer
 // for forward compatibility
@@ -135,6 +305,13 @@ type GoProcServer interface {
 	Stdout(context.Context, *StdoutProcessRequest) (*StdoutProcessResponse, error)
 	Stderr(context.Context, *StderrProcessRequest) (*StderrProcessResponse, error)
 	ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error)
+	WatchEvents(*WatchEventsRequest, GoProc_WatchEventsServer) error
+	StreamOutput(*StreamOutputRequest, GoProc_StreamOutputServer) error
+	Attach(GoProc_AttachServer) error
+	ExecUpload(GoProc_ExecUploadServer) error
+	KillMatching(context.Context, *KillMatchingRequest) (*SignalMatchingResponse, error)
+	SignalMatching(context.Context, *SignalMatchingRequest) (*SignalMatchingResponse, error)
+	WaitAll(context.Context, *WaitAllRequest) (*WaitAllResponse, error)
 	mustEmbedUnimplementedGoProcServer()
 }
 
@@ -166,6 +343,27 @@ func (UnimplementedGoProcServer) Stderr(context.Context, *StderrProcessRequest)
 func (UnimplementedGoProcServer) ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProcesses not implemented")
 }
+func (UnimplementedGoProcServer) WatchEvents(*WatchEventsRequest, GoProc_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedGoProcServer) StreamOutput(*StreamOutputRequest, GoProc_StreamOutputServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOutput not implemented")
+}
+func (UnimplementedGoProcServer) Attach(GoProc_AttachServer) error {
+	return status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
+func (UnimplementedGoProcServer) ExecUpload(GoProc_ExecUploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExecUpload not implemented")
+}
+func (UnimplementedGoProcServer) KillMatching(context.Context, *KillMatchingRequest) (*SignalMatchingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillMatching not implemented")
+}
+func (UnimplementedGoProcServer) SignalMatching(context.Context, *SignalMatchingRequest) (*SignalMatchingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignalMatching not implemented")
+}
+func (UnimplementedGoProcServer) WaitAll(context.Context, *WaitAllRequest) (*WaitAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaitAll not implemented")
+}
 func (UnimplementedGoProcServer) mustEmbedUnimplementedGoProcServer() {}
 
 // UnsafeGoProcServer may be embedded to opt out of forward compatibility for this service.
@@ -323,6 +521,154 @@ func _GoProc_ListProcesses_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoProc_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoProcServer).WatchEvents(m, &goProcWatchEventsServer{stream})
+}
+
+type GoProc_WatchEventsServer interface {
+	Send(*ProcessEvent) error
+	grpc.ServerStream
+}
+
+type goProcWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *goProcWatchEventsServer) Send(m *ProcessEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoProc_StreamOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoProcServer).StreamOutput(m, &goProcStreamOutputServer{stream})
+}
+
+type GoProc_StreamOutputServer interface {
+	Send(*OutputChunk) error
+	grpc.ServerStream
+}
+
+type goProcStreamOutputServer struct {
+	grpc.ServerStream
+}
+
+func (x *goProcStreamOutputServer) Send(m *OutputChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoProc_Attach_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GoProcServer).Attach(&goProcAttachServer{stream})
+}
+
+type GoProc_AttachServer interface {
+	Send(*OutputChunk) error
+	Recv() (*AttachRequest, error)
+	grpc.ServerStream
+}
+
+type goProcAttachServer struct {
+	grpc.ServerStream
+}
+
+func (x *goProcAttachServer) Send(m *OutputChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *goProcAttachServer) Recv() (*AttachRequest, error) {
+	m := new(AttachRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GoProc_ExecUpload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GoProcServer).ExecUpload(&goProcExecUploadServer{stream})
+}
+
+type GoProc_ExecUploadServer interface {
+	SendAndClose(*ExecProcessResponse) error
+	Recv() (*ExecUploadRequest, error)
+	grpc.ServerStream
+}
+
+type goProcExecUploadServer struct {
+	grpc.ServerStream
+}
+
+func (x *goProcExecUploadServer) SendAndClose(m *ExecProcessResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *goProcExecUploadServer) Recv() (*ExecUploadRequest, error) {
+	m := new(ExecUploadRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GoProc_KillMatching_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillMatchingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoProcServer).KillMatching(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoProc_KillMatching_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoProcServer).KillMatching(ctx, req.(*KillMatchingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoProc_SignalMatching_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalMatchingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoProcServer).SignalMatching(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoProc_SignalMatching_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoProcServer).SignalMatching(ctx, req.(*SignalMatchingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoProc_WaitAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoProcServer).WaitAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoProc_WaitAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoProcServer).WaitAll(ctx, req.(*WaitAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GoProc_ServiceDesc is the grpc.ServiceDesc for GoProc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -362,7 +708,41 @@ var GoProc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProcesses",
 			Handler:    _GoProc_ListProcesses_Handler,
 		},
+		{
+			MethodName: "KillMatching",
+			Handler:    _GoProc_KillMatching_Handler,
+		},
+		{
+			MethodName: "SignalMatching",
+			Handler:    _GoProc_SignalMatching_Handler,
+		},
+		{
+			MethodName: "WaitAll",
+			Handler:    _GoProc_WaitAll_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _GoProc_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamOutput",
+			Handler:       _GoProc_StreamOutput_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Attach",
+			Handler:       _GoProc_Attach_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExecUpload",
+			Handler:       _GoProc_ExecUpload_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "goproc.proto",
 }